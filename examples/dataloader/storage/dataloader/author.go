@@ -13,35 +13,30 @@ type AuthorLoader struct {
 }
 
 func NewAuthorLoader(db storage.DBTX) *AuthorLoader {
-	return &AuthorLoader{
+	l := &AuthorLoader{
 		db: db,
 	}
+	l.innerLoader = dataloader.NewBatchedLoader(l.loadBatch)
+	return l
 }
 
-func (l *AuthorLoader) getInnerLoader() *dataloader.Loader[int64, storage.Author] {
-	if l.innerLoader == nil {
-		l.innerLoader = dataloader.NewBatchedLoader(
-			func(ctx context.Context, keys []int64) []*dataloader.Result[storage.Author] {
-				authorMap, err := l.findItemsMap(ctx, keys)
+func (l *AuthorLoader) loadBatch(ctx context.Context, keys []int64) []*dataloader.Result[storage.Author] {
+	authorMap, err := l.findItemsMap(ctx, keys)
 
-				result := make([]*dataloader.Result[storage.Author], len(keys))
-				for i, key := range keys {
-					if err != nil {
-						result[i] = &dataloader.Result[storage.Author]{Error: err}
-						continue
-					}
+	result := make([]*dataloader.Result[storage.Author], len(keys))
+	for i, key := range keys {
+		if err != nil {
+			result[i] = &dataloader.Result[storage.Author]{Error: err}
+			continue
+		}
 
-					if loadedItem, ok := authorMap[key]; ok {
-						result[i] = &dataloader.Result[storage.Author]{Data: loadedItem}
-					} else {
-						result[i] = &dataloader.Result[storage.Author]{Error: pgx.ErrNoRows}
-					}
-				}
-				return result
-			},
-		)
+		if loadedItem, ok := authorMap[key]; ok {
+			result[i] = &dataloader.Result[storage.Author]{Data: loadedItem}
+		} else {
+			result[i] = &dataloader.Result[storage.Author]{Error: pgx.ErrNoRows}
+		}
 	}
-	return l.innerLoader
+	return result
 }
 
 func (l *AuthorLoader) findItemsMap(ctx context.Context, keys []int64) (map[int64]storage.Author, error) {
@@ -71,5 +66,5 @@ func (l *AuthorLoader) findItemsMap(ctx context.Context, keys []int64) (map[int6
 }
 
 func (l *AuthorLoader) Load(ctx context.Context, authorKey int64) (storage.Author, error) {
-	return l.getInnerLoader().Load(ctx, authorKey)()
+	return l.innerLoader.Load(ctx, authorKey)()
 }
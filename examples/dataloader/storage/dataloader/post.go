@@ -13,35 +13,30 @@ type PostLoader struct {
 }
 
 func NewPostLoader(db storage.DBTX) *PostLoader {
-	return &PostLoader{
+	l := &PostLoader{
 		db: db,
 	}
+	l.innerLoader = dataloader.NewBatchedLoader(l.loadBatch)
+	return l
 }
 
-func (l *PostLoader) getInnerLoader() *dataloader.Loader[int64, storage.Post] {
-	if l.innerLoader == nil {
-		l.innerLoader = dataloader.NewBatchedLoader(
-			func(ctx context.Context, keys []int64) []*dataloader.Result[storage.Post] {
-				postMap, err := l.findItemsMap(ctx, keys)
+func (l *PostLoader) loadBatch(ctx context.Context, keys []int64) []*dataloader.Result[storage.Post] {
+	postMap, err := l.findItemsMap(ctx, keys)
 
-				result := make([]*dataloader.Result[storage.Post], len(keys))
-				for i, key := range keys {
-					if err != nil {
-						result[i] = &dataloader.Result[storage.Post]{Error: err}
-						continue
-					}
+	result := make([]*dataloader.Result[storage.Post], len(keys))
+	for i, key := range keys {
+		if err != nil {
+			result[i] = &dataloader.Result[storage.Post]{Error: err}
+			continue
+		}
 
-					if loadedItem, ok := postMap[key]; ok {
-						result[i] = &dataloader.Result[storage.Post]{Data: loadedItem}
-					} else {
-						result[i] = &dataloader.Result[storage.Post]{Error: pgx.ErrNoRows}
-					}
-				}
-				return result
-			},
-		)
+		if loadedItem, ok := postMap[key]; ok {
+			result[i] = &dataloader.Result[storage.Post]{Data: loadedItem}
+		} else {
+			result[i] = &dataloader.Result[storage.Post]{Error: pgx.ErrNoRows}
+		}
 	}
-	return l.innerLoader
+	return result
 }
 
 func (l *PostLoader) findItemsMap(ctx context.Context, keys []int64) (map[int64]storage.Post, error) {
@@ -71,5 +66,5 @@ func (l *PostLoader) findItemsMap(ctx context.Context, keys []int64) (map[int64]
 }
 
 func (l *PostLoader) Load(ctx context.Context, postKey int64) (storage.Post, error) {
-	return l.getInnerLoader().Load(ctx, postKey)()
+	return l.innerLoader.Load(ctx, postKey)()
 }
@@ -0,0 +1,173 @@
+package golang_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	golang "github.com/debugger84/sqlc-graphql/internal"
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/stretchr/testify/require"
+)
+
+// bindPackage is the import path of the testdata/bind directory with the code of sqlc-gen-go the output is bound to.
+const bindPackage = "github.com/debugger84/sqlc-graphql/internal/testdata/bind"
+
+// loaderRaceTest calls the getters of the generated loader factory from the parallel goroutines,
+// it is run with the race detector in the generated loader package.
+const loaderRaceTest = `package loader
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLoaderFactoryRace(t *testing.T) {
+	f := NewLoaderFactory(nil)
+	loaders := make([]*AuthorLoader, 8)
+	var wg sync.WaitGroup
+	for i := range loaders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loaders[i] = f.AuthorLoader()
+		}()
+	}
+	wg.Wait()
+	for _, l := range loaders {
+		if l != loaders[0] || l.innerLoader == nil {
+			t.Fatal("the concurrent resolvers got different loaders")
+		}
+	}
+}
+`
+
+func TestBind(t *testing.T) {
+	ctx := context.Background()
+	t.Run(
+		"Bind the omittable input fields", func(t *testing.T) {
+			dir := genDir(t)
+			pkg := bindPackage + "/" + filepath.Base(dir)
+
+			factory := NewGenReqFactory()
+			factory.options.Package = bindPackage + "/storage"
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.GenCommonParts = true
+			factory.options.EmitOmittableParams = true
+			factory.options.ResolverPackage = pkg + "/delegate"
+			factory.options.ResolverOut = "../delegate"
+			factory.options.MarshalPackage = pkg + "/marshal"
+			factory.options.MarshalOut = "../marshal"
+			factory.query.Text = "update authors set name = sqlc.narg('name') where id = $1 and status = $2 returning id, name, status"
+			factory.query.Name = "UpdateAuthor"
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[0]},
+				{Number: 2, Column: factory.columns[2]},
+				{
+					Number: 3,
+					Column: &plugin.Column{
+						Name:         "name",
+						IsNamedParam: true,
+						Type:         &plugin.Identifier{Name: "text"},
+					},
+				},
+			}
+			factory.query.Comments = []string{"gql: Mutation.updateAuthor"}
+			resp, err := golang.Generate(ctx, factory.GenerateRequest())
+			require.NoError(t, err)
+
+			writeFiles(t, filepath.Join(dir, "out"), resp)
+
+			out, err := goCommand(filepath.Dir(dir), "run", ".", filepath.Base(dir))
+
+			t.Log("Given the input with the nullable parameter generated with emit_omittable_params")
+			t.Log("When gqlgen binds the generated schema to the generated Go code")
+			t.Log("	Then the binding should succeed")
+			require.NoError(t, err, out)
+			t.Log("	And only the nullable input field should be bound to graphql.Omittable")
+			require.Equal(t, "UpdateAuthorInput.name\n", out)
+		},
+	)
+
+	t.Run(
+		"Share the loaders between the concurrent resolvers", func(t *testing.T) {
+			dir := genDir(t)
+			pkg := bindPackage + "/" + filepath.Base(dir)
+
+			factory := NewGenReqFactory()
+			factory.options.Package = bindPackage + "/storage"
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.LoaderPackage = pkg + "/loader"
+			factory.options.LoaderOut = "../loader"
+			factory.options.Relations = []opts.Relation{
+				{
+					From:         "posts.author_id",
+					To:           "authors.id",
+					ReverseField: "-",
+				},
+			}
+			postsIdent := &plugin.Identifier{Schema: factory.schemaName, Name: "posts"}
+			factory.catalog.Schemas[0].Tables = append(
+				factory.catalog.Schemas[0].Tables, &plugin.Table{
+					Rel: postsIdent,
+					Columns: []*plugin.Column{
+						{Name: "id", NotNull: true, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+						{Name: "author_id", NotNull: true, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+					},
+				},
+			)
+			resp, err := golang.Generate(ctx, factory.GenerateRequest())
+			require.NoError(t, err)
+
+			writeFiles(t, filepath.Join(dir, "out"), resp)
+			testFile := filepath.Join(dir, "loader", "loader_race_test.go")
+			require.NoError(t, os.WriteFile(testFile, []byte(loaderRaceTest), 0o644))
+
+			out, err := goCommand(filepath.Dir(dir), "test", "-race", "./"+filepath.Base(dir)+"/loader")
+
+			t.Log("Given the loader factory generated for the relation")
+			t.Log("When its getters are called from the parallel goroutines with the race detector")
+			t.Log("	Then the goroutines should get the same loader without a data race")
+			require.NoError(t, err, out)
+		},
+	)
+}
+
+// genDir creates the directory of the generated files in the testdata/bind module
+// and removes it after the test.
+func genDir(t *testing.T) string {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is required to compile the generated code")
+	}
+	dir, err := os.MkdirTemp("testdata/bind", "gen")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// writeFiles writes the generated files into the "out" directory the way sqlc does.
+func writeFiles(t *testing.T, out string, resp *plugin.GenerateResponse) {
+	for _, file := range resp.Files {
+		name := filepath.Join(out, file.Name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, file.Contents, 0o644))
+	}
+}
+
+// goCommand runs the go command in the directory of the testdata/bind module
+// and returns its output, the standard error if it fails.
+func goCommand(dir string, args ...string) (string, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=readonly")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return stdout.String() + stderr.String(), err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,204 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+)
+
+// deprecatedMarker starts the line of the column comment deprecating the column, e.g.
+//
+//	COMMENT ON COLUMN authors.bio IS 'The biography
+//	@deprecated: use profile.bio';
+const deprecatedMarker = "@deprecated"
+
+// defaultDeprecationReason is the default reason of the @deprecated directive
+const defaultDeprecationReason = "No longer supported"
+
+func deprecatedDirective(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return "@deprecated(reason: " + quoteGqlString(reason) + ")"
+}
+
+// quoteGqlString quotes the string with the escape sequences of the GraphQL strings,
+// the \x, \a, \v and \U escapes of Go are not valid there.
+func quoteGqlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04x`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// parseDeprecated parses the reason of the gql-deprecated comment of the query. The comment looks like
+//
+//	-- gql-deprecated: use listAuthorsV2
+func parseDeprecated(comments []string) (string, []string) {
+	for i, comment := range comments {
+		text, ok := strings.CutPrefix(strings.TrimSpace(comment), "gql-deprecated:")
+		if !ok {
+			continue
+		}
+		comments = append(comments[:i], comments[i+1:]...)
+		return deprecationReason(text), comments
+	}
+	return "", comments
+}
+
+// parseDeprecatedComment cuts the line with the deprecation marker out of the comment of the column.
+func parseDeprecatedComment(comment string) (string, string) {
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		text, ok := strings.CutPrefix(strings.TrimSpace(line), deprecatedMarker)
+		// the marker is followed by the reason after the colon or ends the line, @deprecatedSince is not the marker
+		if rest := strings.TrimSpace(text); !ok || rest != "" && !strings.HasPrefix(rest, ":") {
+			continue
+		}
+		lines = append(lines[:i], lines[i+1:]...)
+		return deprecationReason(text), strings.TrimSpace(strings.Join(lines, "\n"))
+	}
+	return "", comment
+}
+
+func deprecationReason(text string) string {
+	reason := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), ":"))
+	if reason == "" {
+		return defaultDeprecationReason
+	}
+	return reason
+}
+
+// deprecateColumns deprecates the fields of the result of the query by the comments of their columns.
+func deprecateColumns(fields []Field) []Field {
+	for i, f := range fields {
+		if f.Column == nil || f.Deprecated != "" {
+			continue
+		}
+		fields[i].Deprecated, _ = parseDeprecatedComment(f.Column.Comment)
+	}
+	return fields
+}
+
+// applyDeprecations deprecates the fields, the arguments, the query fields and the enum values
+// listed in the deprecated option. The keys are case-insensitive like the keys of the exclude option
+// and the fields are named in the field_case style: Author.bio for the field of the type,
+// AuthorsInput.name for the argument of the query,
+// Query.authors for the query field and Status.active for the enum value.
+// The required arguments and input fields can not be deprecated by the GraphQL spec, an error is returned for them.
+func applyDeprecations(options *opts.Options, enums []Enum, structs []Struct, queries []Query) error {
+	if len(options.Deprecated) == 0 {
+		return nil
+	}
+	used := map[string]bool{}
+	reason := func(typeName, fieldName, typ string) (string, error) {
+		for key, reason := range options.Deprecated {
+			if !strings.EqualFold(key, typeName+"."+fieldName) {
+				continue
+			}
+			used[key] = true
+			if strings.HasSuffix(typ, "!") {
+				return "", fmt.Errorf(
+					"the deprecated %s is required, only the nullable arguments and input fields can be deprecated",
+					key,
+				)
+			}
+			if reason == "" {
+				return defaultDeprecationReason, nil
+			}
+			return reason, nil
+		}
+		return "", nil
+	}
+	deprecateFields := func(s *Struct, input bool) error {
+		for i, f := range s.Fields {
+			typ := ""
+			if input {
+				typ = f.Type
+			}
+			r, err := reason(s.Name, gqlFieldName(f.Name, options.Naming.FieldCase), typ)
+			if err != nil {
+				return err
+			}
+			if r != "" {
+				s.Fields[i].Deprecated = r
+			}
+		}
+		return nil
+	}
+
+	for i := range enums {
+		for j, c := range enums[i].Constants {
+			r, _ := reason(enums[i].Name, c.Value, "")
+			if r != "" {
+				enums[i].Constants[j].Deprecated = r
+			}
+		}
+	}
+	for i := range structs {
+		if err := deprecateFields(&structs[i], false); err != nil {
+			return err
+		}
+	}
+	for i, q := range queries {
+		r, _ := reason(q.ExtendedType, q.ResolverName, "")
+		if r != "" {
+			queries[i].Deprecated = r
+		}
+		if q.Arg.Struct != nil {
+			if err := deprecateFields(q.Arg.Struct, true); err != nil {
+				return fmt.Errorf("%s: query %s: %w", q.SourceName, q.MethodName, err)
+			}
+		} else if !q.Arg.isEmpty() {
+			// the single argument is keyed by the name of the input like the arguments made from the fields
+			name, err := inputName(options, q.ResolverName)
+			if err != nil {
+				return fmt.Errorf("%s: query %s: %w", q.SourceName, q.MethodName, err)
+			}
+			r, err := reason(name, q.Arg.Name, q.Arg.Pairs()[0].Type)
+			if err != nil {
+				return fmt.Errorf("%s: query %s: %w", q.SourceName, q.MethodName, err)
+			}
+			queries[i].Arg.Deprecated = r
+		}
+	}
+
+	keys := make([]string, 0, len(options.Deprecated))
+	for key := range options.Deprecated {
+		if !used[key] {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Fprintf(os.Stderr, "WARNING: the deprecated %s is not found in the schema\n", key)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package golang
+
+import "github.com/debugger84/sqlc-graphql/internal/opts"
+
+// parseDriver returns the driver of the sql_package option of sqlc-gen-go.
+func parseDriver(sqlPackage string) opts.SQLDriver {
+	switch sqlPackage {
+	case opts.SQLPackagePGXV4:
+		return opts.SQLDriverPGXV4
+	case opts.SQLPackagePGXV5:
+		return opts.SQLDriverPGXV5
+	default:
+		return opts.SQLDriverLibPQ
+	}
+}
@@ -9,12 +9,15 @@ type Constant struct {
 	Name  string
 	Type  string
 	Value string
+	// Deprecated is the reason of the deprecation of the enum value
+	Deprecated string
 }
 
 type Enum struct {
 	Name      string
 	Comment   string
 	Constants []Constant
+	ModelPath string
 }
 
 func enumReplacer(r rune) rune {
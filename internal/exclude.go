@@ -0,0 +1,225 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+)
+
+// fieldExclusion hides the fields matching the glob patterns of the type and the field names,
+// e.g. Author.name, *.passwordHash (*.password_hash with the snake field_case) or Audit*.*
+type fieldExclusion struct {
+	pattern   string
+	typeName  string
+	fieldName string
+	// output and input are the directions the field is hidden from
+	output  bool
+	input   bool
+	matched bool
+}
+
+type fieldExclusions []*fieldExclusion
+
+// excludes reports whether the field of the output type or of the input is hidden.
+func (e fieldExclusions) excludes(typeName, fieldName string, input bool) bool {
+	excluded := false
+	for _, exclusion := range e {
+		if input && !exclusion.input || !input && !exclusion.output {
+			continue
+		}
+		if matchGlob(exclusion.typeName, typeName) && matchGlob(exclusion.fieldName, fieldName) {
+			exclusion.matched = true
+			excluded = true
+		}
+	}
+	return excluded
+}
+
+func (e fieldExclusions) warnUnmatched() {
+	for _, exclusion := range e {
+		if !exclusion.matched {
+			fmt.Fprintf(os.Stderr, "WARNING: the exclude pattern %s matches no field\n", exclusion.pattern)
+		}
+	}
+}
+
+// matchGlob matches the name case-insensitively with the pattern of path.Match.
+func matchGlob(pattern, name string) bool {
+	ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return ok
+}
+
+func checkGlob(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	return nil
+}
+
+// getGqlExcluded parses the exclude options: exclude hides the fields everywhere,
+// exclude_from_outputs makes them write-only and exclude_from_inputs makes them read-only.
+func getGqlExcluded(options *opts.Options) (fieldExclusions, error) {
+	var res fieldExclusions
+	if options == nil {
+		return nil, nil
+	}
+	add := func(patterns []string, output, input bool) error {
+		for _, exclude := range patterns {
+			parts := strings.Split(exclude, ".")
+			if len(parts) != 2 {
+				return errors.New("invalid exclude format. It should be in the format of 'GqlTypeName.fieldName'")
+			}
+			for _, part := range parts {
+				if err := checkGlob(part); err != nil {
+					return fmt.Errorf("invalid exclude format: %w", err)
+				}
+			}
+			res = append(
+				res, &fieldExclusion{
+					pattern:   exclude,
+					typeName:  parts[0],
+					fieldName: parts[1],
+					output:    output,
+					input:     input,
+				},
+			)
+		}
+		return nil
+	}
+	if err := add(options.Exclude, true, true); err != nil {
+		return nil, err
+	}
+	if err := add(options.ExcludeFromOutputs, true, false); err != nil {
+		return nil, err
+	}
+	if err := add(options.ExcludeFromInputs, false, true); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// filterStructs removes the excluded fields, they are matched by the GraphQL names in the field_case style.
+func filterStructs(structs []Struct, excludeFields fieldExclusions, fieldCase string, input bool) []Struct {
+	var result []Struct
+	for _, s := range structs {
+		var fields []Field
+		for _, f := range s.Fields {
+			if excludeFields.excludes(s.Name, gqlFieldName(f.Name, fieldCase), input) {
+				continue
+			}
+			fields = append(fields, f)
+		}
+		s.Fields = fields
+		result = append(result, s)
+	}
+	return result
+}
+
+// typeExclusion removes the types, the enums or the queries with the names matching the glob pattern.
+type typeExclusion struct {
+	pattern string
+	matched bool
+}
+
+func newTypeExclusions(patterns []string) []*typeExclusion {
+	res := make([]*typeExclusion, 0, len(patterns))
+	for _, p := range patterns {
+		res = append(res, &typeExclusion{pattern: p})
+	}
+	return res
+}
+
+func excludesName(exclusions []*typeExclusion, name string) bool {
+	excluded := false
+	for _, exclusion := range exclusions {
+		if matchGlob(exclusion.pattern, name) {
+			exclusion.matched = true
+			excluded = true
+		}
+	}
+	return excluded
+}
+
+func warnUnmatchedNames(option string, exclusions []*typeExclusion) {
+	for _, exclusion := range exclusions {
+		if !exclusion.matched {
+			fmt.Fprintf(os.Stderr, "WARNING: the %s pattern %s matches nothing\n", option, exclusion.pattern)
+		}
+	}
+}
+
+// excludeQueries removes the queries with the names matching the exclude_queries patterns,
+// so they get neither the fields nor the types of their results.
+func excludeQueries(options *opts.Options, queries []Query) []Query {
+	if len(options.ExcludeQueries) == 0 {
+		return queries
+	}
+	exclusions := newTypeExclusions(options.ExcludeQueries)
+	var res []Query
+	for _, q := range queries {
+		if !excludesName(exclusions, q.MethodName) {
+			res = append(res, q)
+		}
+	}
+	warnUnmatchedNames("exclude_queries", exclusions)
+	return res
+}
+
+// excludeTypes removes the types and the enums matching the exclude_types and exclude_enums patterns.
+// The removed ones must not be referenced by the kept queries and types.
+func excludeTypes(options *opts.Options, enums []Enum, structs []Struct, queries []Query) ([]Enum, []Struct, error) {
+	if len(options.ExcludeTypes) == 0 && len(options.ExcludeEnums) == 0 {
+		return enums, structs, nil
+	}
+	excluded := map[string]struct{}{}
+
+	enumExclusions := newTypeExclusions(options.ExcludeEnums)
+	keepEnums := make([]Enum, 0, len(enums))
+	for _, e := range enums {
+		if excludesName(enumExclusions, e.Name) {
+			excluded[e.Name] = struct{}{}
+			excluded["Null"+e.Name] = struct{}{}
+			continue
+		}
+		keepEnums = append(keepEnums, e)
+	}
+
+	typeExclusions := newTypeExclusions(options.ExcludeTypes)
+	keepStructs := make([]Struct, 0, len(structs))
+	for _, s := range structs {
+		if excludesName(typeExclusions, s.Name) {
+			excluded[s.Name] = struct{}{}
+			continue
+		}
+		keepStructs = append(keepStructs, s)
+	}
+	warnUnmatchedNames("exclude_enums", enumExclusions)
+	warnUnmatchedNames("exclude_types", typeExclusions)
+
+	for _, q := range queries {
+		for _, t := range queryTypes(q) {
+			if _, ok := excluded[t]; ok {
+				return nil, nil, fmt.Errorf(
+					"%s: query %s: the excluded type %s is referenced by the query, exclude the query too",
+					q.SourceName, q.MethodName, t,
+				)
+			}
+		}
+	}
+	for _, s := range keepStructs {
+		for _, f := range s.Fields {
+			if _, ok := excluded[baseType(f.Type)]; ok {
+				return nil, nil, fmt.Errorf(
+					"the excluded type %s is referenced by the field %s.%s",
+					baseType(f.Type), s.Name, sdk.LowerTitle(f.Name),
+				)
+			}
+		}
+	}
+	return keepEnums, keepStructs, nil
+}
@@ -0,0 +1,268 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// EntityType is a type resolved by the _entities query of Apollo Federation.
+// The types of the tables with the primary keys are the entities of the subgraph,
+// and the types of other subgraphs extended by the queries are the stubs with the key only.
+type EntityType struct {
+	Name      string
+	ModelPath string
+	// KeyField is the GraphQL field of the key, rowId for the nodes keyed by id
+	KeyField string
+	// GoField is the field of the key in the model of a stub
+	GoField string
+	// KeyType is the GraphQL type of the key of a stub
+	KeyType string
+	// Key is the primary key of the table or the column referencing the stub
+	Key *plugin.Column
+	// Query fetches the entity by the key, it is nil for the stubs and if there is no such query
+	Query *Query
+	Stub  bool
+}
+
+// addFederationKeys marks the types of the tables with the primary keys as the federation entities
+// and adds the stubs of the extended types of other subgraphs.
+func addFederationKeys(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	structs []Struct,
+	queries []Query,
+	keys map[string]*plugin.Column,
+) ([]Struct, []EntityType, error) {
+	var entities []EntityType
+	names := map[string]struct{}{}
+	for i := range structs {
+		s := &structs[i]
+		names[s.Name] = struct{}{}
+		key := keys[s.Name]
+		idx := keyField(s, key)
+		if idx < 0 {
+			continue
+		}
+		entity := EntityType{
+			Name:      s.Name,
+			ModelPath: s.ModelPath,
+			KeyField:  gqlFieldName(s.Fields[idx].Name, options.Naming.FieldCase),
+			Key:       key,
+		}
+		s.Directive = strings.TrimSpace(fmt.Sprintf("@key(fields: %q) %s", entity.KeyField, s.Directive))
+		for _, q := range queries {
+			if isEntityQuery(q, s, key) {
+				entity.Query = &q
+				if q.Cmd == metadata.CmdOne {
+					break
+				}
+			}
+		}
+		if entity.Query == nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"WARNING: the %s type is an entity, but there is no :one or :batchone query fetching it by %s for the _entities field\n",
+				s.Name, key.Name,
+			)
+		}
+		entities = append(entities, entity)
+	}
+
+	for _, name := range getExtendedTypes(queries) {
+		if _, ok := names[name]; ok || isRootType(name) {
+			continue
+		}
+		stub := EntityType{Name: name, KeyField: "id", GoField: "ID", KeyType: "ID!", Stub: true}
+		if options.ResolverPackage != "" {
+			stub.ModelPath = options.ResolverPackage + "." + name + "Reference"
+		}
+		// the key of the stub has the type of the referencing column, e.g. post_id of the Post type
+		col := findReference(req, name, stub.KeyField)
+		for _, c := range options.FederationStubs {
+			if c.Type != name {
+				continue
+			}
+			if c.Key != "" {
+				stub.KeyField = c.Key
+				stub.GoField = goStructName(toSnakeCase(c.Key))
+				col = findReference(req, name, c.Key)
+			}
+			if c.Column != "" {
+				end, err := findRelationEnd(req, structs, c.Column)
+				if err != nil {
+					return nil, nil, fmt.Errorf("federation stub %s: %w", name, err)
+				}
+				col = end.column
+			}
+		}
+		if col != nil {
+			stub.Key = &plugin.Column{
+				Name:     col.Name,
+				Table:    col.Table,
+				Type:     col.Type,
+				NotNull:  true,
+				Unsigned: col.Unsigned,
+			}
+			stub.KeyType = gqlType(req, options, stub.Key)
+		}
+		entities = append(entities, stub)
+	}
+	return structs, entities, nil
+}
+
+// isEntityQuery reports whether the :one or :batchone query takes only the id and returns the model of the table.
+func isEntityQuery(q Query, s *Struct, key *plugin.Column) bool {
+	if q.Cmd == metadata.CmdBatchOne {
+		q.Cmd = metadata.CmdOne
+		q.Arg.List = false
+	}
+	return isNodeQuery(q, s, key)
+}
+
+func isRootType(name string) bool {
+	return name == "Query" || name == "Mutation" || name == "Subscription"
+}
+
+// findReference returns the column referencing the type of another subgraph by the naming convention,
+// e.g. post_id references Post keyed by id.
+func findReference(req *plugin.GenerateRequest, name string, key string) *plugin.Column {
+	column := toSnakeCase(name) + "_" + toSnakeCase(key)
+	for _, schema := range req.Catalog.Schemas {
+		for _, table := range schema.Tables {
+			for _, c := range table.Columns {
+				if c.Name == column {
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// buildEntityResolvers builds the resolver of the _entities field dispatching the representations
+// to the queries of the entities and to the references of the stubs.
+// The keys of the entities fetched by the :batchone queries are collected first,
+// so every such query is called once with all the keys of its type.
+func buildEntityResolvers(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	entities []EntityType,
+	imports *goImports,
+) []goResolver {
+	imports.add(schemaPackage)
+	imports.add("fmt")
+	r := goResolver{
+		Receiver:   "QueryDelegate",
+		Name:       "Entities",
+		FieldName:  "_entities",
+		Args:       []goArgument{{Name: "representations", Type: "[]map[string]interface{}"}},
+		ReturnType: "[]" + imports.Model(schemaPackage+".Entity"),
+		Body:       []string{"res = make([]" + imports.Model(schemaPackage+".Entity") + ", len(representations))"},
+	}
+	var cases, batches []string
+	for _, e := range entities {
+		if !e.Stub && e.Query == nil || e.Stub && e.ModelPath == "" {
+			continue
+		}
+		keyType := "string"
+		if e.Key != nil {
+			keyType = imports.Type(goType(req, options, e.Key))
+		}
+		cases = append(
+			cases,
+			fmt.Sprintf("case %q:", e.Name),
+			fmt.Sprintf("k, err := schema.EntityKey[%s](r, %q)", keyType, e.KeyField),
+			"if err != nil {",
+			"return nil, err",
+			"}",
+		)
+		switch {
+		case e.Stub:
+			cases = append(cases, fmt.Sprintf("res[i] = &%s{%s: k}", e.Name+"Reference", e.GoField))
+		case e.Query.Cmd == metadata.CmdBatchOne:
+			keys := toLowerCase(e.Name) + "Keys"
+			positions := toLowerCase(e.Name) + "Positions"
+			r.Body = append(
+				r.Body,
+				fmt.Sprintf("var %s []%s", keys, keyType),
+				fmt.Sprintf("var %s []int", positions),
+			)
+			cases = append(
+				cases,
+				fmt.Sprintf("%s = append(%s, k)", keys, keys),
+				fmt.Sprintf("%s = append(%s, i)", positions, positions),
+			)
+			batches = append(
+				batches,
+				fmt.Sprintf("if len(%s) > 0 {", keys),
+				fmt.Sprintf("results := d.%s.%s(ctx, %s)", options.ResolverQueriesField, e.Query.MethodName, keys),
+				"results.QueryRow(func(j int, item "+imports.Model(e.ModelPath)+", e error) {",
+				"if e != nil {",
+				"if err == nil {",
+				"err = e",
+				"}",
+				"return",
+				"}",
+				fmt.Sprintf("res[%s[j]] = &item", positions),
+				"})",
+				"results.Close()",
+				"if err != nil {",
+				"return nil, err",
+				"}",
+				"}",
+			)
+		default:
+			cases = append(
+				cases,
+				fmt.Sprintf("row, err := d.%s.%s(ctx, k)", options.ResolverQueriesField, e.Query.MethodName),
+				"if err != nil {",
+				"return nil, err",
+				"}",
+				"res[i] = &row",
+			)
+		}
+	}
+	r.Body = append(
+		r.Body,
+		"for i, r := range representations {",
+		`typ, _ := r["__typename"].(string)`,
+		"switch typ {",
+	)
+	r.Body = append(r.Body, cases...)
+	r.Body = append(
+		r.Body,
+		"default:",
+		`return nil, fmt.Errorf("entity type %s is not found", typ)`,
+		"}",
+		"}",
+	)
+	r.Body = append(r.Body, batches...)
+	r.Body = append(r.Body, "return res, nil")
+	return []goResolver{r}
+}
+
+// buildEntityReferences builds the models of the stubs holding the keys of the entities of other subgraphs.
+func buildEntityReferences(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	entities []EntityType,
+	imports *goImports,
+) []goReference {
+	var refs []goReference
+	for _, e := range entities {
+		if !e.Stub || e.ModelPath == "" {
+			continue
+		}
+		keyType := "string"
+		if e.Key != nil {
+			keyType = imports.Type(goType(req, options, e.Key))
+		}
+		refs = append(refs, goReference{Name: e.Name + "Reference", Entity: e.Name, GoField: e.GoField, KeyType: keyType})
+	}
+	return refs
+}
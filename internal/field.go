@@ -17,6 +17,12 @@ type Field struct {
 	// EmbedFields contains the embedded fields that require scanning.
 	EmbedFields []Field
 	Directive   string
+	// Deprecated is the reason of the deprecation of the field
+	Deprecated string
+	// Relation is true for the field of the relation resolved by a loader
+	Relation bool
+	// Omittable is true for the nullable input field bound to graphql.Omittable
+	Omittable bool
 }
 
 func (gf Field) HasSqlcSlice() bool {
@@ -0,0 +1,208 @@
+package golang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// filterPlaceholder is schema.FilterPlaceholder replaced with the condition chosen by the client.
+const filterPlaceholder = "/* filter */ TRUE"
+
+// Filter is the condition on the columns listed in the gql-filter comment of the list query.
+// Its input and the inputs of the operators are bound to maps,
+// which are turned into the SQL predicate by schema.FilterClause.
+type Filter struct {
+	// Name is the name of the filtered type
+	Name    string
+	Columns []FilterColumn
+	// Emit is false if the same input is declared by another query
+	Emit bool
+}
+
+func (f Filter) InputName() string {
+	return f.Name + "Filter"
+}
+
+// FilterColumn is a field of the filter input with the operators of the type of the column.
+type FilterColumn struct {
+	Name string
+	// Table is the table of the column or its alias in the query, it qualifies the column in the condition
+	Table string
+	// DBName is the column of the table the column of the result is selected from
+	DBName string
+	Type   string
+	// Enum is the GraphQL enum of the column, its values are turned into the values stored in the database
+	Enum string
+}
+
+// ScalarFilter is the input with the operators of the columns of a scalar or an enum.
+type ScalarFilter struct {
+	Type     string
+	In       bool
+	Contains bool
+	Ordered  bool
+}
+
+func (f ScalarFilter) Name() string {
+	return f.Type + "Filter"
+}
+
+// scalarFilters are the operators of the filtered scalars, the enums are compared with eq and in.
+var scalarFilters = []ScalarFilter{
+	{Type: "String", In: true, Contains: true, Ordered: true},
+	{Type: "ID", In: true},
+	{Type: "Int", In: true, Ordered: true},
+	{Type: "Float", In: true, Ordered: true},
+	{Type: "Boolean"},
+	{Type: "Time", In: true, Ordered: true},
+	{Type: "UUID", In: true},
+	{Type: "Int64", In: true, Ordered: true},
+	{Type: "BigInt", In: true, Ordered: true},
+	{Type: "Decimal", In: true, Ordered: true},
+}
+
+// parseFilter parses the columns of the gql-filter comment of the query. The comment looks like
+//
+//	-- gql-filter: name, status, created_at
+func parseFilter(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	comments []string,
+	columns []*plugin.Column,
+) ([]FilterColumn, []string, error) {
+	for i, comment := range comments {
+		text, ok := strings.CutPrefix(strings.TrimSpace(comment), "gql-filter:")
+		if !ok {
+			continue
+		}
+		comments = append(comments[:i], comments[i+1:]...)
+
+		var filterColumns []FilterColumn
+		for _, name := range strings.Split(text, ",") {
+			name = strings.TrimSpace(name)
+			var col *plugin.Column
+			for j, c := range columns {
+				if c.EmbedTable == nil && columnName(c, j) == name {
+					col = c
+				}
+			}
+			if col == nil {
+				return nil, nil, fmt.Errorf("filter column %s is not found in the result of the query", name)
+			}
+			// the condition is put into WHERE, where the aliases of the result are not visible
+			if col.Table == nil {
+				return nil, nil, fmt.Errorf("filter column %s is not a column of a table", name)
+			}
+			typ := gqlType(req, options, col)
+			filter, ok := findScalarFilter(req, options, typ)
+			if !ok {
+				return nil, nil, fmt.Errorf("filter column %s of type %s is not supported", name, typ)
+			}
+			fc := FilterColumn{
+				Name:   gqlFieldName(StructName(name, options), options.Naming.FieldCase),
+				Table:  col.Table.Name,
+				DBName: col.Name,
+				Type:   filter.Name(),
+			}
+			if col.TableAlias != "" {
+				fc.Table = col.TableAlias
+			}
+			if col.OriginalName != "" {
+				fc.DBName = col.OriginalName
+			}
+			if findEnum(req, options, filter.Type) != nil {
+				fc.Enum = filter.Type
+			}
+			if !slices.Contains(filterColumns, fc) {
+				filterColumns = append(filterColumns, fc)
+			}
+		}
+		return filterColumns, comments, nil
+	}
+	return nil, comments, nil
+}
+
+// findScalarFilter returns the operators of the GraphQL type of a column, the lists can not be filtered.
+func findScalarFilter(req *plugin.GenerateRequest, options *opts.Options, typ string) (ScalarFilter, bool) {
+	if strings.HasPrefix(typ, "[") {
+		return ScalarFilter{}, false
+	}
+	typ = baseType(typ)
+	for _, f := range scalarFilters {
+		if f.Type == typ {
+			return f, true
+		}
+	}
+	if findEnum(req, options, typ) != nil {
+		return ScalarFilter{Type: typ, In: true}, true
+	}
+	return ScalarFilter{}, false
+}
+
+// findEnum returns the enum of the GraphQL type or nil if the type is not an enum.
+func findEnum(req *plugin.GenerateRequest, options *opts.Options, typ string) *Enum {
+	for _, e := range buildEnums(req, options) {
+		if e.Name == typ {
+			return &e
+		}
+	}
+	return nil
+}
+
+// emitFilterInputs declares the filter inputs once for each filtered type.
+func emitFilterInputs(queries []Query) error {
+	declared := map[string]Query{}
+	for i, q := range queries {
+		if q.Filter == nil {
+			continue
+		}
+		d, ok := declared[q.Filter.Name]
+		if !ok {
+			q.Filter.Emit = true
+			declared[q.Filter.Name] = queries[i]
+			continue
+		}
+		// the columns of the tables may be aliased differently in the queries, only the fields of the input are compared
+		sameFields := slices.EqualFunc(d.Filter.Columns, q.Filter.Columns, func(a, b FilterColumn) bool {
+			return a.Name == b.Name && a.Type == b.Type && a.Enum == b.Enum
+		})
+		if !sameFields {
+			return fmt.Errorf(
+				"%s is filtered by different columns in the queries %s and %s",
+				q.Filter.Name, d.MethodName, q.MethodName,
+			)
+		}
+	}
+	return nil
+}
+
+// usedScalarFilters returns the operator inputs of the filtered scalars declared in common.graphql
+// and of the filtered enums declared next to the enums.
+func usedScalarFilters(req *plugin.GenerateRequest, options *opts.Options, queries []Query) ([]ScalarFilter, []ScalarFilter) {
+	used := map[string]struct{}{}
+	for _, q := range queries {
+		if q.Filter == nil {
+			continue
+		}
+		for _, c := range q.Filter.Columns {
+			used[c.Type] = struct{}{}
+		}
+	}
+	var scalars, enums []ScalarFilter
+	for _, f := range scalarFilters {
+		if _, ok := used[f.Name()]; ok {
+			scalars = append(scalars, f)
+		}
+	}
+	for _, e := range buildEnums(req, options) {
+		f := ScalarFilter{Type: e.Name, In: true}
+		if _, ok := used[f.Name()]; ok {
+			enums = append(enums, f)
+		}
+	}
+	return scalars, enums
+}
@@ -3,6 +3,7 @@ package golang
 import (
 	"context"
 	"fmt"
+
 	"github.com/debugger84/sqlc-graphql/internal/opts"
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
 )
@@ -29,21 +30,89 @@ func Generate(ctx context.Context, req *plugin.GenerateRequest) (*plugin.Generat
 	if err != nil {
 		return nil, err
 	}
+	queries = excludeQueries(options, queries)
 	structs = addRetValuesToStructs(structs, queries)
+	structs, loaders, err := addRelationFields(req, options, structs)
+	if err != nil {
+		return nil, err
+	}
+	enums, structs, err = excludeTypes(options, enums, structs, queries)
+	if err != nil {
+		return nil, err
+	}
 
 	if options.OmitUnusedStructs {
 		enums, structs = filterUnusedStructs(enums, structs, queries)
 	}
 
+	// the nodes and the entities share the primary keys of the tables
+	var keys map[string]*plugin.Column
+	if options.EmitNodeInterface || options.Federation {
+		if keys, err = tableKeys(req, options, structs, queries); err != nil {
+			return nil, err
+		}
+	}
+	var nodes []NodeType
+	if options.EmitNodeInterface {
+		structs, nodes = addNodeFields(options, structs, queries, keys)
+	}
+	var entities []EntityType
+	if options.Federation {
+		structs, entities, err = addFederationKeys(req, options, structs, queries, keys)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := applyDeprecations(options, enums, structs, queries); err != nil {
+		return nil, err
+	}
+
 	if err := validate(enums, structs, queries); err != nil {
 		return nil, err
 	}
 
-	resp, err := generateGql(req, options, enums, structs, queries)
+	var marshalers []pgtypeMarshaler
+	if options.MarshalPackage != "" {
+		marshalers = usedPgtypeMarshalers(req, options)
+	}
+
+	resp, err := generateGql(req, options, enums, structs, queries, nodes, entities, marshalers)
 	if err != nil {
 		return nil, err
 	}
 
+	if options.ResolverPackage != "" {
+		files, err := generateResolvers(req, options, structs, queries, nodes, entities)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, files...)
+	}
+
+	if options.LoaderPackage != "" && len(loaders) > 0 {
+		files, err := generateLoaders(req, options, loaders)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, files...)
+	}
+
+	if len(marshalers) > 0 {
+		files, err := generateMarshalers(req, options, marshalers)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, files...)
+	}
+
+	if options.SchemaLock != "" {
+		lock, err := checkSchemaLock(req, options, resp.Files)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, lock)
+	}
+
 	return resp, nil
 }
 
@@ -53,12 +122,33 @@ func validate(enums []Enum, structs []Struct, queries []Query) error {
 		enumNames[enum.Name] = struct{}{}
 		enumNames["Null"+enum.Name] = struct{}{}
 	}
-	structNames := make(map[string]struct{})
+	// the types and the inputs share the names, e.g. a naming template ignoring .Name names them all the same
+	typeModels := make(map[string]string)
+	checkName := func(name, modelPath string) error {
+		if other, ok := typeModels[name]; ok && other != modelPath {
+			return fmt.Errorf("the type name %s is generated for both %s and %s", name, other, modelPath)
+		}
+		typeModels[name] = modelPath
+		return nil
+	}
 	for _, struckt := range structs {
 		if _, ok := enumNames[struckt.Name]; ok {
 			return fmt.Errorf("struct name conflicts with enum name: %s", struckt.Name)
 		}
-		structNames[struckt.Name] = struct{}{}
+		if err := checkName(struckt.Name, struckt.ModelPath); err != nil {
+			return err
+		}
+	}
+	for _, query := range queries {
+		if !query.Arg.EmitStruct() {
+			continue
+		}
+		if _, ok := enumNames[query.Arg.Struct.Name]; ok {
+			return fmt.Errorf("struct name conflicts with enum name: %s", query.Arg.Struct.Name)
+		}
+		if err := checkName(query.Arg.Struct.Name, query.Arg.ModelPath); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -68,21 +158,31 @@ func filterUnusedStructs(enums []Enum, structs []Struct, queries []Query) ([]Enu
 	keepTypes := make(map[string]struct{})
 
 	for _, query := range queries {
-		if !query.Arg.isEmpty() {
-			keepTypes[query.Arg.Type()] = struct{}{}
-			if query.Arg.IsStruct() {
-				for _, field := range query.Arg.Struct.Fields {
-					keepTypes[field.Type] = struct{}{}
-				}
-			}
+		for _, t := range queryTypes(query) {
+			keepTypes[t] = struct{}{}
 		}
-		if query.hasRetType() {
-			keepTypes[query.Ret.Type()] = struct{}{}
-			if query.Ret.IsStruct() {
-				for _, field := range query.Ret.Struct.Fields {
-					keepTypes[field.Type] = struct{}{}
-					for _, embedField := range field.EmbedFields {
-						keepTypes[embedField.Type] = struct{}{}
+	}
+
+	// the targets of the relations of the kept structs are kept with the types of their fields
+	for added := true; added; {
+		added = false
+		for _, st := range structs {
+			if _, ok := keepTypes[st.Name]; !ok {
+				continue
+			}
+			for _, field := range st.Fields {
+				target := baseType(field.Type)
+				if _, ok := keepTypes[target]; ok || !field.Relation {
+					continue
+				}
+				keepTypes[target] = struct{}{}
+				added = true
+				for _, ts := range structs {
+					if ts.Name != target {
+						continue
+					}
+					for _, f := range ts.Fields {
+						keepTypes[baseType(f.Type)] = struct{}{}
 					}
 				}
 			}
@@ -107,3 +207,28 @@ func filterUnusedStructs(enums []Enum, structs []Struct, queries []Query) ([]Enu
 
 	return keepEnums, keepStructs
 }
+
+// queryTypes returns the types of the arguments and of the result of the query with the types of their fields.
+func queryTypes(query Query) []string {
+	var types []string
+	if !query.Arg.isEmpty() {
+		types = append(types, baseType(query.Arg.Type()))
+		if query.Arg.IsStruct() {
+			for _, field := range query.Arg.Struct.Fields {
+				types = append(types, baseType(field.Type))
+			}
+		}
+	}
+	if query.hasRetType() {
+		types = append(types, baseType(query.Ret.Type()))
+		if query.Ret.IsStruct() {
+			for _, field := range query.Ret.Struct.Fields {
+				types = append(types, baseType(field.Type))
+				for _, embedField := range field.EmbedFields {
+					types = append(types, baseType(embedField.Type))
+				}
+			}
+		}
+	}
+	return types
+}
@@ -3,21 +3,28 @@ package golang
 import (
 	"bufio"
 	"bytes"
-	"errors"
+	"fmt"
 	"github.com/debugger84/sqlc-graphql/internal/opts"
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
 	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+	"os"
 	"slices"
 	"strings"
 	"text/template"
 )
 
 type gqlTmplCtx struct {
-	ModelPackage  string
 	Enums         []Enum
 	Structs       []Struct
 	GoQueries     []Query
 	ExtendedTypes []string
+	Scalars       []Scalar
+	Ranges        []RangeType
+	ScalarFilters []ScalarFilter
+	EnumFilters   []ScalarFilter
+	Nodes         []NodeType
+	Entities      []EntityType
+	Federation    bool
 	SqlcVersion   string
 
 	// TODO: Race conditions
@@ -29,9 +36,6 @@ type gqlTmplCtx struct {
 func (t *gqlTmplCtx) OutputQuery(sourceName string) bool {
 	return t.SourceName == sourceName
 }
-func (t *gqlTmplCtx) ParamsName(InputName string) string {
-	return strings.TrimRight(InputName, "Input") + "Params"
-}
 
 func generateGql(
 	req *plugin.GenerateRequest,
@@ -39,25 +43,40 @@ func generateGql(
 	enums []Enum,
 	structs []Struct,
 	queries []Query,
+	nodes []NodeType,
+	entities []EntityType,
+	marshalers []pgtypeMarshaler,
 ) (*plugin.GenerateResponse, error) {
 	excludedFields, err := getGqlExcluded(options)
 	if err != nil {
 		return nil, err
 	}
-	structs = filterStructs(structs, excludedFields)
+	for _, warning := range int64Warnings(req, options, structs, queries, excludedFields) {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", warning)
+	}
+	structs = filterStructs(structs, excludedFields, options.Naming.FieldCase, false)
 
 	tctx := gqlTmplCtx{
-		ModelPackage:    options.Package,
 		Enums:           enums,
 		Structs:         structs,
 		SqlcVersion:     req.SqlcVersion,
 		OmitSqlcVersion: options.OmitSqlcVersion,
 		GoQueries:       queries,
+		Ranges:          usedRangeTypes(options, structs, queries),
+		Nodes:           nodes,
+		Entities:        entities,
+		Federation:      options.Federation,
 	}
+	tctx.Scalars = usedScalars(structs, queries, tctx.Ranges, marshalers, options.MarshalPackage)
+	tctx.ScalarFilters, tctx.EnumFilters = usedScalarFilters(req, options, queries)
 
 	funcMap := template.FuncMap{
 		"lowerTitle": sdk.LowerTitle,
-		"hasPrefix":  strings.HasPrefix,
+		"deprecated": deprecatedDirective,
+		"fieldName": func(name string) string {
+			return gqlFieldName(name, options.Naming.FieldCase)
+		},
+		"hasPrefix": strings.HasPrefix,
 	}
 
 	tmpl := template.Must(
@@ -75,7 +94,7 @@ func generateGql(
 		var b bytes.Buffer
 		w := bufio.NewWriter(&b)
 		tctx.SourceName = name
-		tctx.GoQueries = filterQueries(name, queries, excludedFields)
+		tctx.GoQueries = filterQueries(name, queries, excludedFields, options.Naming.FieldCase)
 		tctx.ExtendedTypes = getExtendedTypes(tctx.GoQueries)
 		err := tmpl.ExecuteTemplate(w, templateName, &tctx)
 		w.Flush()
@@ -112,6 +131,7 @@ func generateGql(
 			return nil, err
 		}
 	}
+	excludedFields.warnUnmatched()
 	resp := plugin.GenerateResponse{}
 
 	for filename, code := range output {
@@ -155,20 +175,20 @@ func extractGqlCommentsOnly(comments []string) []string {
 
 }
 
-func filterQueries(sourceName string, queries []Query, excludedFields map[string][]string) []Query {
+func filterQueries(sourceName string, queries []Query, excludedFields fieldExclusions, fieldCase string) []Query {
 	var result []Query
 	for _, q := range queries {
 		if q.SourceName == sourceName {
 			q.Comments = extractGqlCommentsOnly(q.Comments)
 			if q.Arg.Struct != nil {
-				args := filterStructs([]Struct{*q.Arg.Struct}, excludedFields)
+				args := filterStructs([]Struct{*q.Arg.Struct}, excludedFields, fieldCase, true)
 				if len(args) == 1 {
 					q.Arg.Struct = &args[0]
 				}
 			}
 
 			if q.Ret.Struct != nil {
-				returns := filterStructs([]Struct{*q.Ret.Struct}, excludedFields)
+				returns := filterStructs([]Struct{*q.Ret.Struct}, excludedFields, fieldCase, false)
 				if len(returns) == 1 {
 					q.Ret.Struct = &returns[0]
 				}
@@ -193,48 +213,3 @@ func getExtendedTypes(queries []Query) []string {
 	slices.Sort(result)
 	return result
 }
-
-func getGqlExcluded(options *opts.Options) (map[string][]string, error) {
-	res := make(map[string][]string)
-	if options == nil || options.Exclude == nil {
-		return nil, nil
-	}
-	for _, exclude := range options.Exclude {
-		parts := strings.Split(exclude, ".")
-
-		if len(parts) == 0 {
-			continue
-		}
-
-		if len(parts) != 2 {
-			return nil, errors.New("invalid exclude format. It should be in the format of 'GqlTypeName.fieldName'")
-		}
-		typeName := strings.ToLower(parts[0])
-		fieldName := strings.ToLower(parts[1])
-		if _, ok := res[typeName]; !ok {
-			res[typeName] = make([]string, 0)
-		}
-		res[typeName] = append(res[typeName], fieldName)
-	}
-	return res, nil
-}
-
-func filterStructs(structs []Struct, excludeFields map[string][]string) []Struct {
-	var result []Struct
-	for _, s := range structs {
-		var fields []Field
-		structName := strings.ToLower(s.Name)
-		for _, f := range s.Fields {
-			if _, ok := excludeFields[structName]; ok {
-				fieldName := strings.ToLower(f.Name)
-				if slices.Contains(excludeFields[structName], fieldName) {
-					continue
-				}
-			}
-			fields = append(fields, f)
-		}
-		s.Fields = fields
-		result = append(result, s)
-	}
-	return result
-}
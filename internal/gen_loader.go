@@ -0,0 +1,152 @@
+package golang
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+type goLoader struct {
+	Name    string
+	Var     string
+	KeyType string
+	Model   string
+	Many    bool
+	// NullableKey is true if the key of the single row can be null,
+	// then Load returns a pointer that is nil for the null key
+	NullableKey bool
+	Query       string
+	Scan        []string
+	// QueryMethod, Keys and ErrNoRows depend on the driver of the sql_package option
+	QueryMethod string
+	Keys        string
+	ErrNoRows   string
+}
+
+// generateLoaders generates the dataloaders resolving the relation fields
+// and the factory creating them for each request.
+func generateLoaders(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	loaders []Loader,
+) ([]*plugin.File, error) {
+	tmpl := newGoTemplate(options)
+	newCtx := func() *goTmplCtx {
+		return &goTmplCtx{
+			Package:         path.Base(options.LoaderPackage),
+			SqlcVersion:     req.SqlcVersion,
+			OmitSqlcVersion: options.OmitSqlcVersion,
+		}
+	}
+
+	var files []*plugin.File
+	factoryImports := newGoImports(options)
+	factoryCtx := newCtx()
+	factoryCtx.DBType = factoryImports.Model(options.Package + ".DBTX")
+	for _, l := range loaders {
+		imports := newGoImports(options, "context", "github.com/graph-gophers/dataloader/v7")
+		tctx := newCtx()
+		tctx.DBType = imports.Model(options.Package + ".DBTX")
+		tctx.Loader = buildGoLoader(req, options, l, imports)
+		tctx.Imports = imports.Groups()
+		f, err := executeGoFile(
+			tmpl,
+			filepath.Join(options.LoaderOut, loaderFileName(l.Name)),
+			"loaderFile",
+			tctx,
+		)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+		factoryCtx.Loaders = append(factoryCtx.Loaders, tctx.Loader)
+	}
+
+	factoryCtx.Imports = factoryImports.Groups()
+	f, err := executeGoFile(tmpl, filepath.Join(options.LoaderOut, "loader_factory.go"), "loaderFactoryFile", factoryCtx)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, f)
+
+	return files, nil
+}
+
+func buildGoLoader(req *plugin.GenerateRequest, options *opts.Options, l Loader, imports *goImports) goLoader {
+	gl := goLoader{
+		Name:        l.Name,
+		Var:         toLowerCase(l.Name),
+		KeyType:     imports.Type(goType(req, options, loaderKey(l))),
+		Model:       imports.Model(l.ModelPath),
+		Many:        l.Many,
+		NullableKey: !l.Many && !l.KeyNotNull,
+		Scan:        []string{"&key"},
+		QueryMethod: "Query",
+		Keys:        "keys",
+	}
+	switch driver := parseDriver(options.SqlPackage); driver {
+	case opts.SQLDriverPGXV4, opts.SQLDriverPGXV5:
+		if !l.Many {
+			imports.add(string(driver))
+			gl.ErrNoRows = "pgx.ErrNoRows"
+		}
+	default:
+		// database/sql does not encode the slices as the arrays of Postgres
+		imports.add("github.com/lib/pq")
+		gl.QueryMethod = "QueryContext"
+		gl.Keys = "pq.Array(keys)"
+		if !l.Many {
+			imports.add("database/sql")
+			gl.ErrNoRows = "sql.ErrNoRows"
+		}
+	}
+
+	table := l.Table.Name
+	if l.Table.Schema != "" && l.Table.Schema != req.Catalog.DefaultSchema {
+		table = l.Table.Schema + "." + table
+	}
+	columns := []string{l.Key.Name}
+	for _, c := range l.Columns {
+		columns = append(columns, c.Name)
+		f := Field{Name: StructName(c.Name, options), DBName: c.Name}
+		gl.Scan = append(gl.Scan, "&item."+goFieldName(f, options))
+	}
+	gl.Query = "SELECT " + strings.Join(columns, ", ") + " FROM " + table + " WHERE " + l.Key.Name + " = ANY($1)"
+
+	return gl
+}
+
+// loaderKey returns the key column of the loader with the nullability of the field
+// of the other side passed to the loader. The rows with the null key are never found.
+func loaderKey(l Loader) *plugin.Column {
+	return &plugin.Column{
+		Name:     l.Key.Name,
+		Table:    l.Key.Table,
+		Type:     l.Key.Type,
+		NotNull:  l.KeyNotNull,
+		IsArray:  l.Key.IsArray,
+		Unsigned: l.Key.Unsigned,
+	}
+}
+
+// loaderFileName converts the name of the loader to the snake case,
+// e.g. PostsByAuthorIDLoader becomes posts_by_author_id_loader.go
+func loaderFileName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String() + ".go"
+}
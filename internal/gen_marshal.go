@@ -0,0 +1,205 @@
+package golang
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// pgtypeMarshaler binds a nullable pgtype wrapper of pgx to a GraphQL scalar,
+// so the nullable columns are resolved without the field resolvers.
+type pgtypeMarshaler struct {
+	// Name is the name of the wrapper in the pgtype package, it names the marshal functions as well
+	Name      string
+	Scalar    string
+	Driver    opts.SQLDriver
+	Marshal   []string
+	Unmarshal []string
+	Imports   []string
+}
+
+// validMarshaler converts the value of a pgx v5 wrapper having the Valid field.
+func validMarshaler(name, scalar, field, unmarshal, marshal, convert string) pgtypeMarshaler {
+	return pgtypeMarshaler{
+		Name:   name,
+		Scalar: scalar,
+		Driver: opts.SQLDriverPGXV5,
+		Marshal: []string{
+			"if !v.Valid {",
+			"return graphql.Null",
+			"}",
+			"return " + fmt.Sprintf(marshal, "v."+field),
+		},
+		Unmarshal: []string{
+			"if v == nil {",
+			"return res, nil",
+			"}",
+			"value, err := " + unmarshal + "(v)",
+			"if err != nil {",
+			"return res, err",
+			"}",
+			"return pgtype." + name + "{" + field + ": " + fmt.Sprintf(convert, "value") + ", Valid: true}, nil",
+		},
+	}
+}
+
+// textMarshaler converts a wrapper by its text representation used in database/sql.
+func textMarshaler(name, scalar string, driver opts.SQLDriver) pgtypeMarshaler {
+	return pgtypeMarshaler{
+		Name:    name,
+		Scalar:  scalar,
+		Driver:  driver,
+		Imports: []string{"fmt"},
+		Marshal: []string{
+			"value, err := v.Value()",
+			"if err != nil || value == nil {",
+			"return graphql.Null",
+			"}",
+			"return graphql.MarshalString(fmt.Sprint(value))",
+		},
+		Unmarshal: []string{
+			"if v == nil {",
+			"err = res.Scan(nil)",
+			"return res, err",
+			"}",
+			"value, err := graphql.UnmarshalString(v)",
+			"if err != nil {",
+			"return res, err",
+			"}",
+			"err = res.Scan(value)",
+			"return res, err",
+		},
+	}
+}
+
+// jsonMarshaler converts a JSON wrapper of pgx v4 keeping the document as is.
+func jsonMarshaler(name string) pgtypeMarshaler {
+	return pgtypeMarshaler{
+		Name:    name,
+		Scalar:  "JSON",
+		Driver:  opts.SQLDriverPGXV4,
+		Imports: []string{schemaPackage},
+		Marshal: []string{
+			"value, err := v.Value()",
+			"if err != nil || value == nil {",
+			"return graphql.Null",
+			"}",
+			"return schema.MarshalJSONBytes(v.Bytes)",
+		},
+		Unmarshal: []string{
+			"if v == nil {",
+			"err = res.Scan(nil)",
+			"return res, err",
+			"}",
+			"doc, err := schema.UnmarshalJSONBytes(v)",
+			"if err != nil {",
+			"return res, err",
+			"}",
+			"err = res.Scan(doc)",
+			"return res, err",
+		},
+	}
+}
+
+var pgtypeMarshalers = []pgtypeMarshaler{
+	validMarshaler("Bool", "Boolean", "Bool", "graphql.UnmarshalBoolean", "graphql.MarshalBoolean(%s)", "%s"),
+	validMarshaler("Date", "Time", "Time", "graphql.UnmarshalTime", "graphql.MarshalTime(%s)", "%s"),
+	validMarshaler("Float4", "Float", "Float32", "graphql.UnmarshalFloat", "graphql.MarshalFloat(float64(%s))", "float32(%s)"),
+	validMarshaler("Float8", "Float", "Float64", "graphql.UnmarshalFloat", "graphql.MarshalFloat(%s)", "%s"),
+	withImports(validMarshaler("Int2", "Int", "Int16", "schema.UnmarshalInt16", "graphql.MarshalInt(int(%s))", "%s"), schemaPackage),
+	validMarshaler("Int4", "Int", "Int32", "graphql.UnmarshalInt32", "graphql.MarshalInt32(%s)", "%s"),
+	validMarshaler("Int8", "Int", "Int64", "graphql.UnmarshalInt64", "graphql.MarshalInt64(%s)", "%s"),
+	withImports(validMarshaler("Int8", "Int64", "Int64", "schema.UnmarshalInt64", "schema.MarshalInt64(%s)", "%s"), schemaPackage),
+	withImports(validMarshaler("Int8", "BigInt", "Int64", "schema.UnmarshalBigInt", "schema.MarshalBigInt(%s)", "%s"), schemaPackage),
+	validMarshaler("Text", "String", "String", "graphql.UnmarshalString", "graphql.MarshalString(%s)", "%s"),
+	validMarshaler("Timestamp", "Time", "Time", "graphql.UnmarshalTime", "graphql.MarshalTime(%s)", "%s"),
+	validMarshaler("Timestamptz", "Time", "Time", "graphql.UnmarshalTime", "graphql.MarshalTime(%s)", "%s"),
+	textMarshaler("Numeric", "String", opts.SQLDriverPGXV5),
+	textMarshaler("Numeric", "Decimal", opts.SQLDriverPGXV5),
+	textMarshaler("UUID", "UUID", opts.SQLDriverPGXV5),
+	textMarshaler("Interval", "String", opts.SQLDriverPGXV5),
+	textMarshaler("Time", "String", opts.SQLDriverPGXV5),
+	textMarshaler("Numeric", "String", opts.SQLDriverPGXV4),
+	textMarshaler("Numeric", "Decimal", opts.SQLDriverPGXV4),
+	textMarshaler("Daterange", "String", opts.SQLDriverPGXV4),
+	textMarshaler("Tsrange", "String", opts.SQLDriverPGXV4),
+	textMarshaler("Tstzrange", "String", opts.SQLDriverPGXV4),
+	textMarshaler("Numrange", "String", opts.SQLDriverPGXV4),
+	textMarshaler("Int4range", "String", opts.SQLDriverPGXV4),
+	textMarshaler("Int8range", "String", opts.SQLDriverPGXV4),
+	jsonMarshaler("JSON"),
+	jsonMarshaler("JSONB"),
+}
+
+// withImports adds the packages used by the marshal functions.
+func withImports(m pgtypeMarshaler, imports ...string) pgtypeMarshaler {
+	m.Imports = append(m.Imports, imports...)
+	return m
+}
+
+// usedPgtypeMarshalers returns the marshalers of the pgtype wrappers
+// used by the columns of the tables and the queries.
+// A wrapper is bound to the scalar its columns are mapped to, e.g. pgtype.Int8 to Int64 or BigInt.
+func usedPgtypeMarshalers(req *plugin.GenerateRequest, options *opts.Options) []pgtypeMarshaler {
+	driver := parseDriver(options.SqlPackage)
+	used := map[string]struct{}{}
+	add := func(col *plugin.Column) {
+		if col != nil {
+			used[goInnerType(req, options, col)+" "+gqlInnerType(req, options, col)] = struct{}{}
+		}
+	}
+	for _, schema := range req.Catalog.Schemas {
+		for _, table := range schema.Tables {
+			for _, col := range table.Columns {
+				add(col)
+			}
+		}
+	}
+	for _, q := range req.Queries {
+		for _, col := range q.Columns {
+			add(col)
+		}
+		for _, p := range q.Params {
+			add(p.Column)
+		}
+	}
+
+	var marshalers []pgtypeMarshaler
+	for _, m := range pgtypeMarshalers {
+		if _, ok := used["pgtype."+m.Name+" "+m.Scalar]; ok && m.Driver == driver {
+			marshalers = append(marshalers, m)
+		}
+	}
+	return marshalers
+}
+
+// generateMarshalers generates the gqlgen marshal functions of the used pgtype wrappers.
+// The scalars are bound to them in common.graphql.
+func generateMarshalers(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	marshalers []pgtypeMarshaler,
+) ([]*plugin.File, error) {
+	imports := newGoImports(options, "github.com/99designs/gqlgen/graphql")
+	imports.Type("pgtype.Text")
+	for _, m := range marshalers {
+		for _, p := range m.Imports {
+			imports.add(p)
+		}
+	}
+	tctx := &goTmplCtx{
+		Package:         path.Base(options.MarshalPackage),
+		SqlcVersion:     req.SqlcVersion,
+		OmitSqlcVersion: options.OmitSqlcVersion,
+		Marshalers:      marshalers,
+		Imports:         imports.Groups(),
+	}
+	f, err := executeGoFile(newGoTemplate(options), filepath.Join(options.MarshalOut, "pgtype.go"), "marshalFile", tctx)
+	if err != nil {
+		return nil, err
+	}
+	return []*plugin.File{f}, nil
+}
@@ -0,0 +1,633 @@
+package golang
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+)
+
+const (
+	schemaPackage = "github.com/debugger84/sqlc-graphql/schema"
+	omittableType = "github.com/99designs/gqlgen/graphql.Omittable"
+)
+
+type goTmplCtx struct {
+	Package      string
+	Imports      [][]string
+	SourceName   string
+	SqlcVersion  string
+	QueriesField string
+	QueriesType  string
+	Delegates    []string
+	NotifierType string
+	Resolvers    []goResolver
+	References   []goReference
+	DBType       string
+	Loaders      []goLoader
+	Loader       goLoader
+	Marshalers   []pgtypeMarshaler
+	Rewriter     *goRewriter
+	Inputs       []goInput
+
+	OmitSqlcVersion bool
+}
+
+type goArgument struct {
+	Name string
+	Type string
+}
+
+// goResolver is a method of the delegate that resolves the field by calling the SQL query.
+type goResolver struct {
+	Receiver  string
+	Name      string
+	FieldName string
+	Args      []goArgument
+	// ReturnType is empty for the resolvers of the input fields returning only the error
+	ReturnType string
+	Body       []string
+}
+
+// goReference is the model of the stub of an entity of another subgraph, it holds only the key.
+type goReference struct {
+	Name    string
+	Entity  string
+	GoField string
+	KeyType string
+}
+
+// goInput is the input of the field with the sort keys or the omittable fields, it embeds the params struct of the query.
+type goInput struct {
+	Name      string
+	FieldName string
+	Params    string
+	// Fields shadow the nullable fields of the params struct with the omittable ones
+	Fields  []goArgument
+	OrderBy string
+}
+
+// goRewriter is the wrapper of the DBTX applying the sort keys and the filters of the context to the queries.
+// The methods and their results depend on the driver of the sql_package option.
+type goRewriter struct {
+	Package  string
+	Query    string
+	Rows     string
+	QueryRow string
+	Row      string
+}
+
+// generateResolvers generates the delegates implementing the gqlgen resolvers of the generated fields.
+// The delegate of each extended type is declared in delegate.go,
+// and its methods are put into a file for each source file of the queries.
+func generateResolvers(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	structs []Struct,
+	queries []Query,
+	nodes []NodeType,
+	entities []EntityType,
+) ([]*plugin.File, error) {
+	tmpl := newGoTemplate(options)
+	execute := func(name, templateName string, tctx *goTmplCtx) (*plugin.File, error) {
+		return executeGoFile(tmpl, filepath.Join(options.ResolverOut, name), templateName, tctx)
+	}
+	newCtx := func() *goTmplCtx {
+		return &goTmplCtx{
+			Package:         path.Base(options.ResolverPackage),
+			SqlcVersion:     req.SqlcVersion,
+			OmitSqlcVersion: options.OmitSqlcVersion,
+			QueriesField:    options.ResolverQueriesField,
+		}
+	}
+
+	var files []*plugin.File
+	var sources []string
+	delegates := map[string]struct{}{}
+	resolvers := map[string][]Query{}
+	for _, q := range queries {
+		if !hasGoResolver(q) {
+			continue
+		}
+		if _, ok := resolvers[q.SourceName]; !ok {
+			sources = append(sources, q.SourceName)
+		}
+		resolvers[q.SourceName] = append(resolvers[q.SourceName], q)
+		delegates[q.ExtendedType] = struct{}{}
+	}
+	// the node field of Query and the id fields of the nodes are resolved by the delegates as well
+	var nodeTypes []string
+	if len(nodes) > 0 {
+		nodeTypes = append(nodeTypes, "Query")
+	}
+	for _, n := range nodes {
+		nodeTypes = append(nodeTypes, n.Name)
+	}
+	// the _entities field of Query dispatches the representations to the queries of the entities
+	if len(entities) > 0 && len(nodes) == 0 {
+		nodeTypes = append(nodeTypes, "Query")
+	}
+	for _, t := range nodeTypes {
+		delegates[t] = struct{}{}
+	}
+	// the range fields of the types and the inputs are converted by the delegates of these types
+	rangeImports := newGoImports(options, "context")
+	ranges := buildRangeResolvers(req, options, structs, queries, rangeImports)
+	for _, r := range ranges {
+		t := strings.TrimSuffix(r.Receiver, "Delegate")
+		if _, ok := delegates[t]; !ok {
+			delegates[t] = struct{}{}
+			nodeTypes = append(nodeTypes, t)
+		}
+	}
+	if len(sources) == 0 && len(nodes) == 0 && len(entities) == 0 && len(ranges) == 0 {
+		return nil, nil
+	}
+
+	for _, source := range sources {
+		imports := newGoImports(options, "context")
+		tctx := newCtx()
+		tctx.SourceName = source
+		for _, q := range resolvers[source] {
+			if q.wrapsParams() {
+				tctx.Inputs = append(tctx.Inputs, buildGoInput(req, options, q, imports))
+			}
+			tctx.Resolvers = append(tctx.Resolvers, buildGoResolver(req, options, q, imports))
+		}
+		tctx.Imports = imports.Groups()
+		f, err := execute(source+".go", "resolverFile", tctx)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	if len(nodes) > 0 {
+		imports := newGoImports(options, "context")
+		tctx := newCtx()
+		tctx.Resolvers = buildNodeResolvers(req, options, nodes, imports)
+		tctx.Imports = imports.Groups()
+		f, err := execute("node.go", "resolverFile", tctx)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	if len(entities) > 0 {
+		imports := newGoImports(options, "context")
+		tctx := newCtx()
+		tctx.References = buildEntityReferences(req, options, entities, imports)
+		tctx.Resolvers = buildEntityResolvers(req, options, entities, imports)
+		tctx.Imports = imports.Groups()
+		f, err := execute("entity.go", "resolverFile", tctx)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	if len(ranges) > 0 {
+		tctx := newCtx()
+		tctx.Resolvers = ranges
+		tctx.Imports = rangeImports.Groups()
+		f, err := execute("range.go", "resolverFile", tctx)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	for _, q := range queries {
+		if (q.Sort != nil || q.Filter != nil) && hasGoResolver(q) {
+			f, err := execute("query_rewriter.go", "queryRewriterFile", buildGoRewriter(options, newCtx()))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, f)
+			break
+		}
+	}
+
+	imports := newGoImports(options)
+	tctx := newCtx()
+	tctx.QueriesType = imports.Model(options.Package + ".Queries")
+	for _, q := range queries {
+		if q.Notify != "" && hasGoResolver(q) {
+			tctx.NotifierType = imports.Model(schemaPackage + ".Notifier")
+		}
+	}
+	for _, t := range append(getExtendedTypes(queries), nodeTypes...) {
+		if _, ok := delegates[t]; ok {
+			tctx.Delegates = append(tctx.Delegates, t)
+			delete(delegates, t)
+		}
+	}
+	tctx.Imports = imports.Groups()
+	f, err := execute("delegate.go", "delegateFile", tctx)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, f)
+
+	return files, nil
+}
+
+func newGoTemplate(options *opts.Options) *template.Template {
+	funcMap := template.FuncMap{
+		"lowerTitle": sdk.LowerTitle,
+		"deprecated": deprecatedDirective,
+		"fieldName": func(name string) string {
+			return gqlFieldName(name, options.Naming.FieldCase)
+		},
+		"hasPrefix": strings.HasPrefix,
+	}
+
+	return template.Must(
+		template.New("table").
+			Funcs(funcMap).
+			ParseFS(
+				templates,
+				"templates/*.tmpl",
+			),
+	)
+}
+
+// buildGoInput builds the input embedding the params struct of the query.
+// The omittable fields shadow the fields of the params struct and are copied to them by the resolver,
+// the fields not sent by the client are left null.
+func buildGoInput(req *plugin.GenerateRequest, options *opts.Options, q Query, imports *goImports) goInput {
+	in := goInput{
+		Name:      q.Arg.DefineType(),
+		FieldName: q.ResolverName,
+		Params:    imports.Model(options.Package + "." + q.MethodName + "Params"),
+	}
+	for _, f := range q.Arg.Struct.Fields {
+		if f.Omittable {
+			typ := imports.Type(goType(req, options, f.Column))
+			in.Fields = append(
+				in.Fields,
+				goArgument{Name: goFieldName(f, options), Type: imports.Model(omittableType) + "[" + typ + "]"},
+			)
+		}
+	}
+	if q.Sort != nil {
+		in.OrderBy = imports.Model(schemaPackage + ".OrderBy")
+	}
+	return in
+}
+
+func buildGoRewriter(options *opts.Options, tctx *goTmplCtx) *goTmplCtx {
+	imports := newGoImports(options, "context", schemaPackage)
+	tctx.DBType = imports.Model(options.Package + ".DBTX")
+	r := &goRewriter{Package: path.Base(options.Package)}
+	switch driver := parseDriver(options.SqlPackage); driver {
+	case opts.SQLDriverPGXV4, opts.SQLDriverPGXV5:
+		imports.add(string(driver))
+		r.Query, r.Rows, r.QueryRow, r.Row = "Query", "pgx.Rows", "QueryRow", "pgx.Row"
+	default:
+		imports.add("database/sql")
+		r.Query, r.Rows, r.QueryRow, r.Row = "QueryContext", "*sql.Rows", "QueryRowContext", "*sql.Row"
+	}
+	tctx.Rewriter = r
+	tctx.Imports = imports.Groups()
+	return tctx
+}
+
+// executeGoFile renders the Go file and formats it.
+func executeGoFile(tmpl *template.Template, name, templateName string, tctx *goTmplCtx) (*plugin.File, error) {
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+	err := tmpl.ExecuteTemplate(w, templateName, tctx)
+	w.Flush()
+	if err != nil {
+		return nil, err
+	}
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %w", name, err)
+	}
+	return &plugin.File{
+		Name:     name,
+		Contents: code,
+	}, nil
+}
+
+// hasGoResolver reports whether the resolver of the query field can be generated.
+func hasGoResolver(q Query) bool {
+	if hasRangeValue(q) {
+		return false
+	}
+	// the subscriptions stream the results of the queries for the notified keys only
+	if q.ExtendedType == "Subscription" {
+		return q.Notify != "" && !q.Ret.isEmpty() && q.FieldType() != ""
+	}
+	switch q.Cmd {
+	case metadata.CmdOne, metadata.CmdMany:
+		return !q.Ret.isEmpty() && q.FieldType() != ""
+	case metadata.CmdBatchMany, metadata.CmdBatchOne:
+		return !q.Ret.isEmpty() && q.FieldType() != ""
+	case metadata.CmdExec, metadata.CmdExecRows, metadata.CmdExecLastId, metadata.CmdExecResult,
+		metadata.CmdBatchExec, metadata.CmdCopyFrom:
+		return q.FieldType() != ""
+	}
+	return false
+}
+
+func buildGoResolver(req *plugin.GenerateRequest, options *opts.Options, q Query, imports *goImports) goResolver {
+	r := goResolver{
+		Receiver:  q.ExtendedType + "Delegate",
+		Name:      goName(q.ResolverName),
+		FieldName: q.ResolverName,
+	}
+
+	if q.Notify != "" {
+		item := goReturnType(req, options, q, imports)
+		if q.Cmd == metadata.CmdMany {
+			item = "[]" + item
+		}
+		imports.add(schemaPackage)
+		r.ReturnType = "<-chan " + item
+		r.Body = append(
+			r.Body,
+			fmt.Sprintf(
+				"return schema.Subscribe(ctx, d.Notifier, %q, d.%s.%s)",
+				q.Notify, options.ResolverQueriesField, q.MethodName,
+			),
+		)
+		return r
+	}
+
+	// the value passed to the query method
+	param := ""
+	paramsType := options.Package + "." + q.MethodName + "Params"
+	switch {
+	case q.wrapsParams():
+		// the input with the sort keys or the omittable fields is declared next to the resolver and embeds the params struct
+		param = q.Arg.Name + "." + goModelName(paramsType)
+		r.Args = append(r.Args, goArgument{Name: q.Arg.Name, Type: q.Arg.DefineType()})
+		for _, f := range q.Arg.Struct.Fields {
+			if f.Omittable {
+				name := goFieldName(f, options)
+				r.Body = append(r.Body, param+"."+name+" = "+q.Arg.Name+"."+name+".Value()")
+			}
+		}
+	case q.Arg.EmitStruct():
+		param = q.Arg.Name
+		r.Args = append(r.Args, goArgument{Name: param, Type: listPrefix(q.Arg) + imports.Model(q.Arg.ModelPath)})
+	case q.Arg.IsStruct():
+		param = "params"
+		fields := make([]string, 0, len(q.Arg.Struct.Fields))
+		for _, f := range q.Arg.Struct.Fields {
+			name := escape(toLowerCase(f.Name))
+			arg, value := buildRangeArgument(req, options, name, f.Type, f.Column, imports, &r)
+			r.Args = append(r.Args, arg)
+			fields = append(fields, goFieldName(f, options)+": "+value)
+		}
+		r.Body = append(r.Body, param+" := "+imports.Model(paramsType)+"{"+strings.Join(fields, ", ")+"}")
+	case !q.Arg.isEmpty():
+		var arg goArgument
+		arg, param = buildRangeArgument(req, options, escape(q.Arg.Name), q.Arg.Typ, q.Arg.Column, imports, &r)
+		arg.Type = listPrefix(q.Arg) + arg.Type
+		r.Args = append(r.Args, arg)
+	}
+
+	if len(q.Hidden) > 0 && param == "" {
+		// all the parameters of the query are hidden
+		if h := q.Hidden[0]; h.GoField == "" {
+			param = escape(h.Name)
+			r.Body = append(r.Body, "var "+param+" "+imports.Type(goType(req, options, h.Column)))
+		} else {
+			param = "params"
+			r.Body = append(r.Body, "var "+param+" "+imports.Model(paramsType))
+		}
+	}
+	for _, h := range q.Hidden {
+		target := param
+		if h.GoField != "" {
+			target += "." + h.GoField
+		}
+		typ := imports.Type(goType(req, options, h.Column))
+		imports.add(schemaPackage)
+		r.Body = append(
+			r.Body,
+			fmt.Sprintf("if %s, err = schema.HiddenParam[%s](ctx, %q); err != nil {", target, typ, h.Name),
+			"return res, err",
+			"}",
+		)
+	}
+
+	// the sort keys and the filter are applied to the query by the QueryRewriter of the queries
+	if q.Sort != nil {
+		columns := make([]string, 0, len(q.Sort.Columns))
+		for _, c := range q.Sort.Columns {
+			columns = append(columns, strconv.Quote(c))
+		}
+		imports.add(schemaPackage)
+		r.Body = append(
+			r.Body,
+			"if ctx, err = schema.WithOrderBy(ctx, "+q.Arg.Name+".OrderBy, "+strings.Join(columns, ", ")+"); err != nil {",
+			"return res, err",
+			"}",
+		)
+	}
+	if q.Filter != nil {
+		imports.add(schemaPackage)
+		r.Args = append(r.Args, goArgument{Name: "filter", Type: "map[string]interface{}"})
+		r.Body = append(r.Body, "if ctx, err = schema.WithFilter(ctx, filter, map[string]schema.FilterColumn{")
+		for _, c := range q.Filter.Columns {
+			column := "Table: " + strconv.Quote(c.Table) + ", Name: " + strconv.Quote(c.DBName)
+			if e := findEnum(req, options, c.Enum); e != nil {
+				values := make([]string, 0, len(e.Constants))
+				for _, v := range e.Constants {
+					values = append(values, strconv.Quote(sdk.LowerTitle(v.Value))+": "+strconv.Quote(v.Value))
+				}
+				column += ", Values: map[string]string{" + strings.Join(values, ", ") + "}"
+			}
+			r.Body = append(r.Body, strconv.Quote(c.Name)+": {"+column+"},")
+		}
+		r.Body = append(r.Body, "}); err != nil {", "return res, err", "}")
+	}
+
+	call := "d." + options.ResolverQueriesField + "." + q.MethodName + "(ctx"
+	if param != "" {
+		call += ", " + param
+	}
+	call += ")"
+
+	switch q.Cmd {
+	case metadata.CmdExec:
+		r.ReturnType = "bool"
+		r.Body = append(r.Body, "err = "+call, "return err == nil, err")
+	case metadata.CmdExecRows, metadata.CmdCopyFrom:
+		r.ReturnType = "int"
+		r.Body = append(r.Body, "rows, err := "+call, "return int(rows), err")
+	case metadata.CmdExecLastId:
+		// the ID scalar is bound to a string by default
+		r.ReturnType = "string"
+		imports.add("strconv")
+		r.Body = append(r.Body, "id, err := "+call, "return strconv.FormatInt(id, 10), err")
+	case metadata.CmdExecResult:
+		r.ReturnType = imports.Model(schemaPackage + ".ExecResult")
+		r.Body = append(
+			r.Body,
+			"result, err := "+call,
+			"if err != nil {",
+			"return res, err",
+			"}",
+			"return schema.NewExecResult(result)",
+		)
+	case metadata.CmdBatchExec:
+		r.ReturnType = "bool"
+		r.Body = append(
+			r.Body,
+			"results := "+call,
+			"defer results.Close()",
+			"results.Exec(func(_ int, e error) {",
+			"if e != nil && err == nil {",
+			"err = e",
+			"}",
+			"})",
+			"return err == nil, err",
+		)
+	case metadata.CmdBatchOne, metadata.CmdBatchMany:
+		item, method := goReturnType(req, options, q, imports), "QueryRow"
+		if q.Cmd == metadata.CmdBatchMany {
+			item, method = "[]"+item, "Query"
+		}
+		r.ReturnType = "[]" + item
+		r.Body = append(
+			r.Body,
+			"results := "+call,
+			"defer results.Close()",
+			"results."+method+"(func(_ int, item "+item+", e error) {",
+			"if e != nil {",
+			"if err == nil {",
+			"err = e",
+			"}",
+			"return",
+			"}",
+			"res = append(res, item)",
+			"})",
+			"return res, err",
+		)
+	case metadata.CmdOne:
+		r.ReturnType = goReturnType(req, options, q, imports)
+		if a, ok := retRangeAdapter(req, options, q); ok {
+			r.ReturnType = a.OutputType(imports)
+			r.Body = append(
+				r.Body,
+				"row, err := "+call,
+				"if err != nil {",
+				"return res, err",
+				"}",
+				"return "+a.From(imports, "row"),
+			)
+		} else if q.Ret.IsStruct() && !strings.HasSuffix(q.FieldType(), "!") {
+			r.ReturnType = "*" + r.ReturnType
+			r.Body = append(
+				r.Body,
+				"row, err := "+call,
+				"if err != nil {",
+				"return nil, err",
+				"}",
+				"return &row, nil",
+			)
+		} else {
+			r.Body = append(r.Body, "return "+call)
+		}
+	case metadata.CmdMany:
+		switch {
+		case q.Paginated && q.CursorPagination:
+			r.ReturnType = imports.Model(q.Ret.ModelPath + "Connection")
+		case q.Paginated:
+			r.ReturnType = imports.Model(q.Ret.ModelPath + "Page")
+		default:
+			r.ReturnType = "[]" + goReturnType(req, options, q, imports)
+		}
+		a, ok := retRangeAdapter(req, options, q)
+		if !ok {
+			r.Body = append(r.Body, "return "+call)
+			break
+		}
+		r.ReturnType = "[]" + a.OutputType(imports)
+		r.Body = append(
+			r.Body,
+			"rows, err := "+call,
+			"if err != nil {",
+			"return res, err",
+			"}",
+			"for _, row := range rows {",
+			"item, err := "+a.From(imports, "row"),
+			"if err != nil {",
+			"return nil, err",
+			"}",
+			"res = append(res, item)",
+			"}",
+			"return res, nil",
+		)
+	}
+
+	return r
+}
+
+// buildRangeArgument returns the argument of the resolver passing the parameter of the query and the value passed to it.
+// The range argument is the adapter converted to the value of pgx before calling the query.
+func buildRangeArgument(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	name string,
+	gqlType string,
+	column *plugin.Column,
+	imports *goImports,
+	r *goResolver,
+) (goArgument, string) {
+	typ := goType(req, options, column)
+	a, ok := newRangeAdapter(typ)
+	if !ok || !isRangeType(gqlType) {
+		return goArgument{Name: name, Type: imports.Type(typ)}, name
+	}
+	value := strings.TrimSuffix(name, "_") + "Value"
+	r.Body = append(
+		r.Body,
+		value+", err := "+a.To(imports, name),
+		"if err != nil {",
+		"return res, err",
+		"}",
+	)
+	return goArgument{Name: name, Type: a.InputType(imports, gqlType)}, value
+}
+
+// retRangeAdapter returns the adapter of the range returned by the query outside of a model.
+func retRangeAdapter(req *plugin.GenerateRequest, options *opts.Options, q Query) (rangeAdapter, bool) {
+	if q.Ret.IsStruct() || q.Ret.isEmpty() || !isRangeType(q.Ret.Type()) {
+		return rangeAdapter{}, false
+	}
+	return newRangeAdapter(goType(req, options, q.Ret.Column))
+}
+
+// listPrefix returns the slice prefix of the Go type of the value passed to the batch queries.
+func listPrefix(v QueryValue) string {
+	if v.List {
+		return "[]"
+	}
+	return ""
+}
+
+func goReturnType(req *plugin.GenerateRequest, options *opts.Options, q Query, imports *goImports) string {
+	if q.Ret.IsStruct() {
+		// the row of the query or the model of the table
+		return imports.Model(q.Ret.ModelPath)
+	}
+	return imports.Type(goType(req, options, q.Ret.Column))
+}
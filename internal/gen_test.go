@@ -3,11 +3,18 @@ package golang_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	golang "github.com/debugger84/sqlc-graphql/internal"
 	"github.com/debugger84/sqlc-graphql/internal/opts"
 	"github.com/gkampitakis/go-snaps/snaps"
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
 	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -60,6 +67,408 @@ func TestGenerate(t *testing.T) {
 				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
 		},
 	)
+	t.Run(
+		"Generate the backward pagination of the cursor paginated query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.EmitBackwardPagination = true
+			req := factory.GenerateRequest()
+			req.Queries[0].Comments = append(
+				req.Queries[0].Comments,
+				"gql: Query.authorsPaginated",
+				"paginated:cursor:name,id",
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the cursor paginated query and the emit_backward_pagination option")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the input should have the optional fields of both directions bound to the params struct")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				if file.Name != "authors.graphql" {
+					continue
+				}
+				require.Contains(t, string(file.Contents), `first: Int @goField(name: "limit")`)
+				require.Contains(t, string(file.Contents), `after: String @goField(name: "cursor")`)
+				require.Contains(t, string(file.Contents), "last: Int")
+				require.Contains(t, string(file.Contents), "before: String")
+				require.NotContains(t, string(file.Contents), `@goField(name: "last")`)
+				require.NotContains(t, string(file.Contents), `@goField(name: "before")`)
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+	t.Run(
+		"Fail on the unknown cursor column", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Comments = []string{
+				"gql: Query.authors",
+				"paginated: cursor:created_at desc,id desc",
+			}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the query paginated by the column that is not in the result of the query")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: cursor column created_at is not found in the result of the query",
+			)
+		},
+	)
+	t.Run(
+		"Generate sortable paginated query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.query.Text = "select id, name, status from authors where status = $1 order by /* order by */ id"
+			factory.query.Name = "ListAuthors"
+			factory.query.Cmd = ":many"
+			factory.query.Params = []*plugin.Parameter{{Number: 1, Column: factory.columns[2]}}
+			factory.query.Comments = []string{
+				"gql: Query.authors",
+				"paginated: offset",
+				"gql-sort: name, status",
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the paginated query with the gql-sort comment is passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the input of the field should have the orderBy field of the sort columns")
+			t.Log("	And the input should be bound to the struct generated next to the resolvers")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), "authors(request: AuthorsInput!): AuthorPage!")
+					require.Contains(t, string(file.Contents), "    orderBy: [AuthorOrderBy!]\n}")
+					require.Contains(t, string(file.Contents), `@goModel(model: "authors/graph/delegate.AuthorsInput")`)
+				case "common.graphql":
+					require.Contains(t, string(file.Contents), "enum SortDirection")
+					continue
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on sorting by the unknown column", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Cmd = ":many"
+			factory.query.Comments = []string{
+				"gql: Query.authors",
+				"paginated: offset",
+				"gql-sort: name, created_at",
+			}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the query sorted by the column that is not in the result of the query")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: sort column created_at is not found in the result of the query",
+			)
+		},
+	)
+
+	t.Run(
+		"Generate filterable paginated query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			factory.query.Text = "select id, name, status from authors where /* filter */ TRUE"
+			factory.query.Name = "ListAuthors"
+			factory.query.Cmd = ":many"
+			factory.query.Params = nil
+			factory.query.Comments = []string{
+				"gql: Query.authors",
+				"paginated: offset",
+				"gql-filter: name, status",
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the paginated query with the gql-filter comment is passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the field should have the filter argument with the operators of the column types")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), "filter: AuthorFilter")
+					require.Contains(t, string(file.Contents), "status: StatusFilter")
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), "input StatusFilter")
+				case "common.graphql":
+					require.Contains(t, string(file.Contents), "input StringFilter")
+					require.NotContains(t, string(file.Contents), "input UUIDFilter")
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on filtering by the unknown column", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Cmd = ":many"
+			factory.query.Comments = []string{
+				"gql: Query.authors",
+				"paginated: offset",
+				"gql-filter: name, created_at",
+			}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the query filtered by the column that is not in the result of the query")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: filter column created_at is not found in the result of the query",
+			)
+		},
+	)
+
+	t.Run(
+		"Fail on filtering the query of the engine other than PostgreSQL", func(t *testing.T) {
+			factory := NewGenReqFactory().SetEngine("mysql")
+			factory.query.Text = "select id, name, status from authors where /* filter */ TRUE"
+			factory.query.Cmd = ":many"
+			factory.query.Comments = []string{"gql: Query.authors", "gql-filter: name"}
+
+			_, err := golang.Generate(ctx, factory.GenerateRequest())
+
+			t.Log("Given the query with the gql-filter comment of the mysql engine")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: gql-filter is supported only by the postgresql engine, not by mysql",
+			)
+		},
+	)
+
+	t.Run(
+		"Generate the delegate applying the sort keys and the filter to the query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.catalog.Schemas[0].Enums[0].Vals = []string{"active", "Banned"}
+			factory.query.Text = "select id, name, status from authors where /* filter */ TRUE order by /* order by */ id"
+			factory.query.Name = "ListAuthors"
+			factory.query.Cmd = ":many"
+			factory.query.Params = nil
+			factory.query.Comments = []string{
+				"gql: Query.authors",
+				"paginated: offset",
+				"gql-sort: name, status",
+				"gql-filter: name, status",
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the offset paginated query with the gql-sort and gql-filter comments and the resolver package")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the field should take the input with the orderBy field and the filter argument")
+			t.Log("	And the delegate should put them into the context of the query wrapped by the QueryRewriter")
+			require.NotNil(t, resp)
+			var names []string
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				switch file.Name {
+				case "authors.graphql":
+					require.Contains(
+						t,
+						string(file.Contents),
+						"authors(request: AuthorsInput!,filter: AuthorFilter): AuthorPage!",
+					)
+				case "delegate/authors.sql.go":
+					t.Log("	And the input should embed the params struct of the query")
+					require.Contains(t, string(file.Contents), "type AuthorsInput struct {\n\tstorage.ListAuthorsParams\n")
+					require.Contains(t, string(file.Contents), "d.Queries.ListAuthors(ctx, request.ListAuthorsParams)")
+				case "delegate/query_rewriter.go":
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+			require.Contains(t, names, "delegate/query_rewriter.go")
+		},
+	)
+
+	t.Run(
+		"Filter by the columns of the aliased and joined tables", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			postsIdent := &plugin.Identifier{Schema: factory.schemaName, Name: "posts"}
+			authorsIdent := factory.columns[0].Table
+			factory.query.Text = "select a.id, a.name as author_name, p.title from authors a " +
+				"join posts p on p.author_id = a.id where /* filter */ TRUE"
+			factory.query.Name = "ListAuthorPosts"
+			factory.query.Cmd = ":many"
+			factory.query.Params = nil
+			factory.query.Columns = []*plugin.Column{
+				{Name: "id", NotNull: true, Table: authorsIdent, TableAlias: "a", Type: &plugin.Identifier{Name: "uuid"}},
+				{
+					Name:         "author_name",
+					OriginalName: "name",
+					Table:        authorsIdent,
+					TableAlias:   "a",
+					Type:         &plugin.Identifier{Name: "text"},
+				},
+				{Name: "title", NotNull: true, Table: postsIdent, TableAlias: "p", Type: &plugin.Identifier{Name: "text"}},
+			}
+			factory.query.Comments = []string{
+				"gql: Query.authorPosts",
+				"paginated: offset",
+				"gql-filter: id, author_name, title",
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the filtered query of the joined tables with the aliases and the aliased column")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the filter should refer to the columns of the tables qualified by their aliases")
+			require.NotNil(t, resp)
+			var names []string
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				if file.Name == "delegate/authors.sql.go" {
+					require.Contains(t, string(file.Contents), `{Table: "a", Name: "id"},`)
+					require.Contains(t, string(file.Contents), `{Table: "a", Name: "name"},`)
+					require.Contains(t, string(file.Contents), `{Table: "p", Name: "title"},`)
+					snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+						MatchStandaloneSnapshot(t, string(file.Contents))
+				}
+			}
+			require.Contains(t, names, "delegate/authors.sql.go")
+		},
+	)
+
+	t.Run(
+		"Fail on filtering by the computed column", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Text = "select id, upper(name) as title from authors where /* filter */ TRUE"
+			factory.query.Cmd = ":many"
+			factory.query.Columns = []*plugin.Column{
+				factory.columns[0],
+				{Name: "title", Type: &plugin.Identifier{Name: "text"}},
+			}
+			factory.query.Comments = []string{"gql: Query.authors", "gql-filter: title"}
+
+			_, err := golang.Generate(ctx, factory.GenerateRequest())
+
+			t.Log("Given the query filtered by the column computed by the expression")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: filter column title is not a column of a table",
+			)
+		},
+	)
+
+	t.Run(
+		"Fail on sorting the cursor paginated query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Text = "select id, name, status from authors order by /* order by */ id"
+			factory.query.Cmd = ":many"
+			factory.query.Comments = []string{
+				"gql: Query.authors",
+				"paginated: cursor:name,id",
+				"gql-sort: name",
+			}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the cursor paginated query with the gql-sort comment")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: gql-sort requires the offset pagination, "+
+					"the cursor pagination is ordered by the cursor columns",
+			)
+		},
+	)
+
+	t.Run(
+		"Fail on sorting the query without the resolver package", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Text = "select id, name, status from authors order by /* order by */ id"
+			factory.query.Cmd = ":many"
+			factory.query.Comments = []string{"gql: Query.authors", "paginated: offset", "gql-sort: name"}
+
+			_, err := golang.Generate(ctx, factory.GenerateRequest())
+
+			t.Log("Given the sorted query and no resolver_package option")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: gql-sort requires the resolver_package option to declare the input with the sort keys",
+			)
+		},
+	)
+
+	t.Run(
+		"Fail on the query without the placeholders of the sort keys and the filter", func(t *testing.T) {
+			sortFactory := NewGenReqFactory()
+			sortFactory.query.Cmd = ":many"
+			sortFactory.query.Comments = []string{"gql: Query.authors", "paginated: offset", "gql-sort: name"}
+			filterFactory := NewGenReqFactory()
+			filterFactory.query.Cmd = ":many"
+			filterFactory.query.Comments = []string{"gql: Query.authors", "gql-filter: name"}
+
+			_, sortErr := golang.Generate(ctx, sortFactory.GenerateRequest())
+			_, filterErr := golang.Generate(ctx, filterFactory.GenerateRequest())
+
+			t.Log("Given the queries with the gql-sort and gql-filter comments but without the placeholders")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return the errors")
+			require.EqualError(
+				t, sortErr,
+				"authors.sql: query GetAuthor: gql-sort requires the /* order by */ placeholder before the default sort keys of the query",
+			)
+			require.EqualError(
+				t, filterErr,
+				"authors.sql: query GetAuthor: gql-filter requires the /* filter */ TRUE placeholder in the condition of the query",
+			)
+		},
+	)
+
 	t.Run(
 		"Generate offset pagination query", func(t *testing.T) {
 			factory := NewGenReqFactory()
@@ -81,213 +490,2214 @@ func TestGenerate(t *testing.T) {
 
 			resp, err := golang.Generate(ctx, req)
 
-			t.Log("Given the 'select * from authors' SQL query is passed to the generator")
-			t.Log("Given the comments with gql and paginated are added to the query")
-			t.Log("When the generator is called")
+			t.Log("Given the 'select * from authors' SQL query is passed to the generator")
+			t.Log("Given the comments with gql and paginated are added to the query")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the response should contain the generated code")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 2)
+			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
+			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+		},
+	)
+
+	t.Run(
+		"Exclude field", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Exclude = []string{"Author.name"}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the option to exclude the 'name' field is passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the response should not contain the name field in the generated Author type")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 2)
+			for _, file := range resp.Files {
+				if file.Name == "schema.graphql" {
+					snaps.WithConfig(snaps.Ext("."+file.Name)).
+						MatchStandaloneSnapshot(t, string(file.Contents))
+					require.NotContains(t, string(file.Contents), "name:")
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Exclude fields by patterns from the outputs and the inputs", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Text = "insert into authors (name, status) values ($1, $2) returning *"
+			factory.query.Name = "CreateAuthor"
+			factory.query.Comments = []string{"gql: Mutation.createAuthor"}
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[1]},
+				{Number: 2, Column: factory.columns[2]},
+			}
+			factory.options.ExcludeFromOutputs = []string{"*.na*"}
+			factory.options.ExcludeFromInputs = []string{"*Input.status"}
+			factory.options.Exclude = []string{"Audit*.*"}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the pattern excluding the name from the outputs and the status from the inputs")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the Author type should have the status but not the name")
+			t.Log("	And the input should have the name but not the status")
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "schema.graphql":
+					require.NotContains(t, string(file.Contents), "name:")
+					require.Contains(t, string(file.Contents), "status: Status!")
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), "name: String")
+					require.NotContains(t, string(file.Contents), "status:")
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on the invalid exclude pattern", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Exclude = []string{"Author.[name"}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the exclude option with the invalid glob pattern")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(t, err, "invalid exclude format: invalid pattern [name: syntax error in pattern")
+		},
+	)
+
+	t.Run(
+		"Exclude and deprecate the fields by the names of the snake field case", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Naming.FieldCase = "snake"
+			factory.options.Exclude = []string{"*.password_hash"}
+			factory.options.Deprecated = map[string]string{"Author.created_at": "use updated_at"}
+			table := factory.catalog.Schemas[0].Tables[0]
+			table.Columns = append(
+				table.Columns,
+				&plugin.Column{
+					Name:    "password_hash",
+					NotNull: true,
+					Table:   table.Rel,
+					Type:    &plugin.Identifier{Name: "text"},
+				},
+				&plugin.Column{
+					Name:  "created_at",
+					Table: table.Rel,
+					Type:  &plugin.Identifier{Name: "timestamptz"},
+				},
+			)
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the snake field case and the exclude and deprecated options with the snake case field names")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the excluded field should be hidden and the deprecated field should be marked")
+			require.NotNil(t, resp)
+			var names []string
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				if file.Name == "schema.graphql" {
+					require.NotContains(t, string(file.Contents), "password_hash")
+					require.Contains(t, string(file.Contents), `created_at: Time @deprecated(reason: "use updated_at")`)
+				}
+			}
+			require.Contains(t, names, "schema.graphql")
+		},
+	)
+
+	t.Run(
+		"Exclude the types, the enums and the queries", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Comments = []string{"gql: Query.author"}
+			schema := factory.catalog.Schemas[0]
+			migrations := &plugin.Identifier{Schema: schema.Name, Name: "schema_migrations"}
+			migrationColumns := []*plugin.Column{
+				{Name: "version", NotNull: true, Table: migrations, Type: &plugin.Identifier{Name: "int4"}},
+				{Name: "action", NotNull: true, Table: migrations, Type: &plugin.Identifier{Name: "audit_action"}},
+			}
+			schema.Tables = append(schema.Tables, &plugin.Table{Rel: migrations, Columns: migrationColumns})
+			schema.Enums = append(schema.Enums, &plugin.Enum{Name: "audit_action", Vals: []string{"up", "down"}})
+			factory.options.ExcludeTypes = []string{"SchemaMigration*"}
+			factory.options.ExcludeEnums = []string{"Audit*"}
+			factory.options.ExcludeQueries = []string{"*Migrations"}
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries, &plugin.Query{
+					Text:     "select version, action from schema_migrations",
+					Name:     "ListMigrations",
+					Cmd:      ":many",
+					Columns:  migrationColumns,
+					Filename: "authors.sql",
+					Comments: []string{"gql: Query.migrations"},
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the table, the enum and the query of the migrations excluded by the patterns")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the schema should not contain the excluded type, enum and query")
+			for _, file := range resp.Files {
+				require.NotContains(t, string(file.Contents), "SchemaMigration")
+				require.NotContains(t, string(file.Contents), "AuditAction")
+				require.NotContains(t, string(file.Contents), "migrations")
+				if file.Name == "schema.graphql" {
+					require.Contains(t, string(file.Contents), "type Author ")
+					snaps.WithConfig(snaps.Ext("."+file.Name)).
+						MatchStandaloneSnapshot(t, string(file.Contents))
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on the excluded type referenced by the query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Comments = []string{"gql: Query.author"}
+			factory.options.ExcludeTypes = []string{"Author"}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the type excluded by the exclude_types option and returned by the query")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: the excluded type Author is referenced by the query, exclude the query too",
+			)
+		},
+	)
+
+	t.Run(
+		"Name the generated types by the naming templates", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Text = "select id, name from authors where status = $1 and name = $2"
+			factory.query.Name = "ListAuthors"
+			factory.query.Cmd = ":many"
+			factory.query.Columns = factory.columns[:2]
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[2]},
+				{Number: 2, Column: factory.columns[1]},
+			}
+			factory.query.Comments = []string{"gql: Query.authors", "paginated: offset"}
+			factory.options.Naming = opts.Naming{
+				Input:     "{{.Name}}Args",
+				Row:       "{{.Name}}Result",
+				Page:      "{{.Name}}List",
+				FieldCase: "snake",
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the naming templates of the input, the row and the page and the snake case of the fields")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the types should be named by the templates")
+			t.Log("	And the @goModel paths should point to the types of sqlc-gen-go")
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), `type ListAuthorsResult @goModel(model: "authors/storage.ListAuthorsRow")`)
+					require.Contains(t, string(file.Contents), `type ListAuthorsResultList @goModel(model: "authors/storage.ListAuthorsRowPage")`)
+					require.Contains(t, string(file.Contents), "has_next: Boolean!")
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), `input AuthorsArgs @goModel(model: "authors/storage.ListAuthorsParams")`)
+					require.Contains(t, string(file.Contents), "authors(request: AuthorsArgs!): ListAuthorsResultList!")
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on the invalid naming template", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Naming.Row = "{{.Name}}-row"
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the row naming template rendering the invalid GraphQL name")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(t, err, `invalid options: naming.row: "Author-row" is not a valid GraphQL name`)
+		},
+	)
+
+	t.Run(
+		"Fail on the naming template failing for the name of the query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Name = "Top"
+			factory.query.Columns = factory.columns[:2]
+			factory.query.Comments = []string{"gql: Query.top"}
+			factory.options.Naming.Row = "{{slice .Name 0 6}}Row"
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the row naming template working for the sample name, but not for the shorter name of the query")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error instead of panicking")
+			require.ErrorContains(t, err, "authors.sql: query Top: naming.row: template: naming:1:2: executing")
+		},
+	)
+
+	t.Run(
+		"Fail on the collision of the generated type names", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Text = "select id, name from authors limit $1 offset $2"
+			factory.query.Name = "ListAuthors"
+			factory.query.Cmd = ":many"
+			factory.query.Columns = factory.columns[:2]
+			factory.query.Comments = []string{"gql: Query.authors", "paginated: offset"}
+			factory.options.Naming.Page = "Page"
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries, &plugin.Query{
+					Text:     "select id, status from authors limit $1 offset $2",
+					Name:     "ListStatuses",
+					Cmd:      ":many",
+					Columns:  []*plugin.Column{factory.columns[0], factory.columns[2]},
+					Filename: "authors.sql",
+					Comments: []string{"gql: Query.statuses", "paginated: offset"},
+				},
+			)
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the page naming template ignoring the name and two paginated queries returning different rows")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error instead of sharing one page between the rows")
+			require.EqualError(
+				t, err,
+				"the type name Page is generated for both authors/storage.ListAuthorsRowPage and authors/storage.ListStatusesRowPage",
+			)
+		},
+	)
+
+	t.Run(
+		"Prefix the generated types of the module", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Comments = []string{"gql: Query.author"}
+			factory.options.TypePrefix = "Blog"
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.options.ResolverQueriesField = "AuthorQueries"
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries,
+				&plugin.Query{
+					Text:    "select id, name from authors where status = $1",
+					Name:    "ListAuthorsByStatus",
+					Cmd:     ":many",
+					Params:  []*plugin.Parameter{{Number: 1, Column: factory.columns[2]}},
+					Columns: factory.columns[:2],
+					Comments: []string{
+						"gql: Post.authors",
+						"paginated: offset",
+					},
+					Filename: "authors.sql",
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the type prefix and the query extending the type of another module")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the types, the enums, the rows, the inputs and the pages should be prefixed")
+			t.Log("	And the @goModel paths and the Go code should use the unprefixed types of sqlc-gen-go")
+			t.Log("	And the extended type of another module should keep its name")
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), `type BlogAuthor @goModel(model: "authors/storage.Author")`)
+					require.Contains(t, string(file.Contents), `enum BlogStatus  @goModel(model: "authors/storage.Status")`)
+					require.Contains(t, string(file.Contents), "status: BlogStatus!")
+					require.Contains(t, string(file.Contents), `type BlogListAuthorsByStatusRowPage @goModel(model: "authors/storage.ListAuthorsByStatusRowPage")`)
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), "extend type Post {")
+					require.Contains(t, string(file.Contents), "author(id: UUID!): BlogAuthor!")
+				case "delegate/authors.sql.go":
+					require.NotContains(t, string(file.Contents), "Blog")
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on the invalid type prefix", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.TypePrefix = "blog-"
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the type prefix which is not a valid GraphQL name")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(t, err, `invalid options: type_prefix "blog-" is not a valid GraphQL name`)
+		},
+	)
+
+	t.Run(
+		"Add directive to query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Directives = []opts.Directive{
+				{
+					Model:     "Query",
+					Field:     "author",
+					Directive: "authGuard",
+				},
+				{
+					Model:     "Author",
+					Field:     "name",
+					Directive: "authGuard",
+				},
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the options with adding directives to the query and the Author type are passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the response should contain authGuard directive in the generated code")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 2)
+			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
+			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+
+			for _, file := range resp.Files {
+				if file.Name == "schema.graphql" {
+					require.Contains(
+						t,
+						string(file.Contents),
+						"name: String @authGuard",
+						"The name field of Author type should contain authGuard directive",
+					)
+				} else {
+					require.Contains(
+						t,
+						string(file.Contents),
+						"author(id: UUID!): Author! @authGuard",
+						"The author query should contain authGuard directive",
+					)
+				}
+			}
+		},
+	)
+	t.Run(
+		"Overwrite SQL type by custom Gql type", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Overrides = []opts.Override{
+				{
+					DBType:  "text",
+					GqlType: "MyText",
+				},
+				{
+					DBType:  "public.status",
+					GqlType: "MyStatus",
+				},
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the options with adding directives to the query and the Author type are passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the response should contain authGuard directive in the generated code")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 2)
+			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
+			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+
+			for _, file := range resp.Files {
+				if file.Name == "schema.graphql" {
+					require.Contains(
+						t,
+						string(file.Contents),
+						"name: MyText",
+						"The name field should be of type MyText",
+					)
+					require.Contains(
+						t,
+						string(file.Contents),
+						"status: MyStatus",
+						"The status field should be of type MyStatus",
+					)
+				} else {
+					require.Contains(
+						t,
+						string(file.Contents),
+						"author(id: UUID!): Author!",
+						"The author query should return Author",
+					)
+				}
+			}
+		},
+	)
+	t.Run(
+		"Overwrite Gql type of a column", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Overrides = []opts.Override{
+				{
+					Column:  "authors.name",
+					GqlType: "MyText",
+				},
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the options with adding directives to the query and the Author type are passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the response should contain authGuard directive in the generated code")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 2)
+			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
+			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+
+			for _, file := range resp.Files {
+				if file.Name == "schema.graphql" {
+					require.Contains(
+						t,
+						string(file.Contents),
+						"name: MyText",
+						"The name field should be of type MyText",
+					)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Generate mutation", func(t *testing.T) {
+
+			factory := NewGenReqFactory()
+			factory.query.Text = "insert into authors (id, name, status) values ($1, $2, $3) returning id, name, status"
+			factory.query.Name = "InsertAuthor"
+			factory.query.Cmd = ":exec"
+			factory.query.Comments = []string{
+				"gql: Mutation.createAuthor",
+			}
+
+			req := factory.GenerateRequest()
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the insert SQL query is passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the response should contain the generated code")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 2)
+			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
+			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
+				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+		},
+	)
+
+	t.Run(
+		"Generate optional arguments for nullable parameters", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.EmitOmittableParams = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.query.Text = "update authors set name = sqlc.narg('name') where id = $1 and status = $2 returning id, name, status"
+			factory.query.Name = "UpdateAuthor"
+			factory.query.Cmd = ":one"
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[0]},
+				{Number: 2, Column: factory.columns[2]},
+				{
+					Number: 3,
+					Column: &plugin.Column{
+						Name:         "name",
+						NotNull:      false,
+						IsNamedParam: true,
+						Type:         &plugin.Identifier{Name: "text"},
+					},
+				},
+			}
+			factory.query.Comments = []string{
+				"gql: Mutation.updateAuthor",
+			}
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries, &plugin.Query{
+					Text:    "select id, name, status from authors where name = sqlc.narg('name')",
+					Name:    "FindAuthorsByName",
+					Cmd:     ":many",
+					Columns: factory.columns,
+					Params: []*plugin.Parameter{
+						{
+							Number: 1,
+							Column: &plugin.Column{
+								Name:         "name",
+								NotNull:      false,
+								IsNamedParam: true,
+								Type:         &plugin.Identifier{Name: "text"},
+							},
+						},
+					},
+					Comments: []string{"gql: Query.authorsByName"},
+					Filename: "authors.sql",
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the queries with nullable parameters are passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the nullable parameters should be optional in the generated code")
+			t.Log("	And the input should be bound to the struct with the omittable field shadowing the field of the params")
+			t.Log("	And the resolver should copy the omittable field to the params")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "authors.graphql":
+					snaps.WithConfig(snaps.Ext("."+file.Name)).
+						MatchStandaloneSnapshot(t, string(file.Contents))
+					require.Contains(t, string(file.Contents), "authorsByName(name: String): [Author!]!")
+					require.Contains(t, string(file.Contents), `input UpdateAuthorInput @goModel(model: "authors/graph/delegate.UpdateAuthorInput")`)
+				case "delegate/authors.sql.go":
+					snaps.WithConfig(snaps.Ext(".go")).
+						MatchStandaloneSnapshot(t, string(file.Contents))
+					require.Contains(t, string(file.Contents), "Name graphql.Omittable[sql.NullString]")
+					require.Contains(t, string(file.Contents), "request.UpdateAuthorParams.Name = request.Name.Value()")
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Generate query from the inline signature", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Comments = []string{
+				`gql: Query.author(id: UUID! @constraint(format: "uuid:v4")): Author @cache(key: "author:id")`,
+			}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the query with the full field definition in the gql comment")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the field should be generated as it is declared")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 2)
+			for _, file := range resp.Files {
+				if file.Name == "authors.graphql" {
+					snaps.WithConfig(snaps.Ext("."+file.Name)).
+						MatchStandaloneSnapshot(t, string(file.Contents))
+					require.Contains(
+						t,
+						string(file.Contents),
+						`author(id: UUID! @constraint(format: "uuid:v4")): Author @cache(key: "author:id")`,
+					)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on the inline signature that does not match the query", func(t *testing.T) {
+			tests := []struct {
+				comment string
+				err     string
+			}{
+				{
+					comment: "gql: Query.author(id: UUID!",
+					err:     `invalid gql signature "Query.author(id: UUID!" at column 23: expected ")", found <EOF>`,
+				},
+				{
+					comment: "gql: Query.author(id: Int!): Author!",
+					err:     "at column 14: argument id: declared type Int! does not match the generated type UUID!",
+				},
+				{
+					comment: "gql: Query.author(key: UUID!): Author!",
+					err:     "at column 14: argument key does not match any parameter of the query",
+				},
+				{
+					comment: "gql: Query.author(id: UUID!): [Author!]!",
+					err:     "at column 26: return type: declared type [Author!]! does not match the generated type Author!",
+				},
+				{
+					comment: "gql: Query.author: Author! @authGuard",
+					err:     "at column 23: directive @authGuard is also added to the field in the options",
+				},
+			}
+			for _, tc := range tests {
+				factory := NewGenReqFactory()
+				factory.options.Directives = []opts.Directive{
+					{
+						Model:     "Query",
+						Field:     "author",
+						Directive: "authGuard",
+					},
+				}
+				factory.query.Comments = []string{tc.comment}
+				req := factory.GenerateRequest()
+
+				_, err := golang.Generate(ctx, req)
+
+				t.Logf("Given the query with the %q comment", tc.comment)
+				t.Log("When the generator is called")
+				t.Log("	Then the generator should return an error with the position of the problem")
+				require.ErrorContains(t, err, "authors.sql: query GetAuthor: ")
+				require.ErrorContains(t, err, tc.err)
+			}
+		},
+	)
+
+	t.Run(
+		"Hide query parameters", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.HiddenParams = []opts.HiddenParam{
+				{
+					Query: "GetMyAuthor",
+					Param: "id",
+					Key:   "currentUserId",
+				},
+			}
+			factory.query.Text = "delete from authors where id = $1 and status = $2"
+			factory.query.Name = "DeleteAuthor"
+			factory.query.Cmd = ":exec"
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[0]},
+				{Number: 2, Column: factory.columns[2]},
+			}
+			factory.query.Comments = []string{
+				"gql: Mutation.deleteAuthor",
+				"gql-hide: status=currentStatus",
+			}
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries, &plugin.Query{
+					Text:    "select id, name, status from authors where id = $1",
+					Name:    "GetMyAuthor",
+					Cmd:     ":one",
+					Columns: factory.columns,
+					Params: []*plugin.Parameter{
+						{Number: 1, Column: factory.columns[0]},
+					},
+					Comments: []string{"gql: Query.me"},
+					Filename: "authors.sql",
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the query with the gql-hide comment and the query with the hidden param in the options")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the hidden params should be replaced by the fromContext directive")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 2)
+			for _, file := range resp.Files {
+				if file.Name == "authors.graphql" {
+					snaps.WithConfig(snaps.Ext("."+file.Name)).
+						MatchStandaloneSnapshot(t, string(file.Contents))
+					require.Contains(
+						t,
+						string(file.Contents),
+						`deleteAuthor(request: DeleteAuthorInput!): Boolean! @fromContext(param: "status", key: "currentStatus")`,
+					)
+					require.Contains(
+						t,
+						string(file.Contents),
+						`me: Author! @fromContext(param: "id", key: "currentUserId")`,
+					)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on hiding the unknown parameter", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Comments = []string{
+				"gql: Query.author",
+				"gql-hide: author_id",
+			}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the query with the gql-hide comment of the param that the query does not have")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(t, err, "authors.sql: query GetAuthor: hidden param author_id is not a parameter of the query")
+		},
+	)
+
+	t.Run(
+		"Generate resolver delegates", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.options.ResolverQueriesField = "AuthorQueries"
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries,
+				&plugin.Query{
+					Text:    "select id, name, status from authors",
+					Name:    "ListAuthors",
+					Cmd:     ":many",
+					Columns: factory.columns,
+					Comments: []string{
+						"gql: Query.authors",
+						"paginated:cursor",
+					},
+					Filename: "authors.sql",
+				},
+				&plugin.Query{
+					Text: "update authors set name = $2 where id = $1",
+					Name: "RenameAuthor",
+					Cmd:  ":execrows",
+					Params: []*plugin.Parameter{
+						{Number: 1, Column: factory.columns[0]},
+						{Number: 2, Column: factory.columns[1]},
+					},
+					Comments: []string{"gql: Mutation.renameAuthor"},
+					Filename: "authors.sql",
+				},
+				&plugin.Query{
+					Text: "delete from authors where id = $1 and status = $2",
+					Name: "DeleteAuthor",
+					Cmd:  ":exec",
+					Params: []*plugin.Parameter{
+						{Number: 1, Column: factory.columns[0]},
+						{Number: 2, Column: factory.columns[2]},
+					},
+					Comments: []string{
+						"gql: Mutation.deleteAuthor",
+						"gql-hide: status",
+					},
+					Filename: "authors.sql",
+				},
+				&plugin.Query{
+					Text:     "select id, name, status from authors where id = $1",
+					Name:     "GetMyAuthor",
+					Cmd:      ":one",
+					Columns:  factory.columns,
+					Params:   []*plugin.Parameter{{Number: 1, Column: factory.columns[0]}},
+					Comments: []string{"gql: Query.me: Author", "gql-hide: id=currentUserId"},
+					Filename: "authors.sql",
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the queries of all the supported commands and the resolver package in the options")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the response should contain the delegates calling the queries")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 4)
+			for _, file := range resp.Files {
+				if strings.HasPrefix(file.Name, "delegate/") {
+					snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+						MatchStandaloneSnapshot(t, string(file.Contents))
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Generate relations with loaders", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.OmitUnusedStructs = true
+			factory.options.LoaderPackage = "authors/storage/loader"
+			factory.options.Relations = []opts.Relation{
+				{
+					From: "posts.author_id",
+					To:   "authors.id",
+				},
+			}
+			postsIdent := &plugin.Identifier{Schema: factory.schemaName, Name: "posts"}
+			factory.catalog.Schemas[0].Tables = append(
+				factory.catalog.Schemas[0].Tables, &plugin.Table{
+					Rel: postsIdent,
+					Columns: []*plugin.Column{
+						{Name: "id", NotNull: true, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+						{Name: "title", NotNull: true, Table: postsIdent, Type: &plugin.Identifier{Name: "text"}},
+						{Name: "author_id", NotNull: false, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+					},
+				},
+			)
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the relation from posts to authors in the options")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the types should have the fields of both sides of the relation")
+			require.NotNil(t, resp)
+			require.Len(t, resp.Files, 5)
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), "author: Author @goField(forceResolver: true)")
+					require.Contains(t, string(file.Contents), "posts: [Post!]! @goField(forceResolver: true)")
+				case "authors.graphql":
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Generate the loaders of the pgx v4 driver for the nullable and not null keys", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.OmitUnusedStructs = true
+			factory.options.SqlPackage = "pgx/v4"
+			factory.options.LoaderPackage = "authors/storage/loader"
+			factory.options.Relations = []opts.Relation{
+				{
+					From:         "posts.editor_id",
+					To:           "authors.id",
+					ReverseField: "-",
+				},
+				{
+					From: "posts.author_id",
+					To:   "authors.id",
+				},
+			}
+			postsIdent := &plugin.Identifier{Schema: factory.schemaName, Name: "posts"}
+			factory.catalog.Schemas[0].Tables = append(
+				factory.catalog.Schemas[0].Tables, &plugin.Table{
+					Rel: postsIdent,
+					Columns: []*plugin.Column{
+						{Name: "id", NotNull: true, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+						{Name: "author_id", NotNull: true, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+						{Name: "editor_id", NotNull: false, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+					},
+				},
+			)
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the relations of the nullable and not null columns to the same table and the pgx v4 driver")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the loaders should take the keys of the types of the columns passing them")
+			require.NotNil(t, resp)
+			var names []string
+			for _, file := range resp.Files {
+				if !strings.HasPrefix(file.Name, "loader/") {
+					continue
+				}
+				names = append(names, path.Base(file.Name))
+				switch path.Base(file.Name) {
+				case "author_nullable_loader.go":
+					t.Log("	And the loader of the nullable key should resolve the null key to nil")
+					require.Contains(
+						t,
+						string(file.Contents),
+						"Load(ctx context.Context, key uuid.NullUUID) (*storage.Author, error)",
+					)
+					require.Contains(t, string(file.Contents), "if key == nullKey {\n\t\treturn nil, nil")
+				case "author_loader.go":
+					require.Contains(
+						t,
+						string(file.Contents),
+						"Load(ctx context.Context, key uuid.UUID) (storage.Author, error)",
+					)
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+			require.ElementsMatch(
+				t,
+				[]string{
+					"author_nullable_loader.go",
+					"author_loader.go",
+					"posts_by_author_id_loader.go",
+					"loader_factory.go",
+				},
+				names,
+			)
+		},
+	)
+
+	t.Run(
+		"Keep the targets of the relations of the used types", func(t *testing.T) {
+			generate := func(relations []opts.Relation) string {
+				factory := NewGenReqFactory()
+				factory.options.OmitUnusedStructs = true
+				factory.options.Relations = relations
+				postsIdent := &plugin.Identifier{Schema: factory.schemaName, Name: "posts"}
+				commentsIdent := &plugin.Identifier{Schema: factory.schemaName, Name: "comments"}
+				factory.catalog.Schemas[0].Enums = append(
+					factory.catalog.Schemas[0].Enums, &plugin.Enum{
+						Name: "post_state",
+						Vals: []string{"draft", "published"},
+					},
+				)
+				factory.catalog.Schemas[0].Tables = append(
+					factory.catalog.Schemas[0].Tables, &plugin.Table{
+						Rel: postsIdent,
+						Columns: []*plugin.Column{
+							{Name: "id", NotNull: true, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+							{Name: "author_id", NotNull: true, Table: postsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+							{
+								Name:    "state",
+								NotNull: true,
+								Table:   postsIdent,
+								Type:    &plugin.Identifier{Schema: factory.schemaName, Name: "post_state"},
+							},
+						},
+					}, &plugin.Table{
+						Rel: commentsIdent,
+						Columns: []*plugin.Column{
+							{Name: "id", NotNull: true, Table: commentsIdent, Type: &plugin.Identifier{Name: "uuid"}},
+						},
+					},
+				)
+				resp, err := golang.Generate(ctx, factory.GenerateRequest())
+				require.NoError(t, err)
+				for _, file := range resp.Files {
+					if file.Name == "schema.graphql" {
+						return string(file.Contents)
+					}
+				}
+				return ""
+			}
+
+			withoutRelations := generate(nil)
+			withRelations := generate([]opts.Relation{{From: "posts.author_id", To: "authors.id"}})
+
+			t.Log("Given the query returning authors and the unused tables of posts and comments")
+			t.Log("When the generator is called with the omit_unused_structs option")
+			t.Log("	Then only the type of the query should be kept without the relations")
+			require.Contains(t, withoutRelations, "type Author ")
+			require.NotContains(t, withoutRelations, "type Post ")
+			require.NotContains(t, withoutRelations, "enum PostState")
+			t.Log("	And the target of the relation should be kept with the types of its fields")
+			require.Contains(t, withRelations, "posts: [Post!]!")
+			require.Contains(t, withRelations, "type Post ")
+			require.Contains(t, withRelations, "enum PostState")
+			t.Log("	And the types unreachable by the relations should be omitted")
+			require.NotContains(t, withRelations, "type Comment ")
+		},
+	)
+
+	t.Run(
+		"Fail on the relation to the unknown column", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Relations = []opts.Relation{
+				{
+					From: "authors.editor_id",
+					To:   "authors.id",
+				},
+			}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the relation from the column that the table does not have")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(
+				t,
+				err,
+				"relation from authors.editor_id to authors.id: column editor_id is not found in the table authors",
+			)
+		},
+	)
+
+	t.Run(
+		"Generate bulk mutations", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			createParams := []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[1]},
+				{Number: 2, Column: factory.columns[2]},
+			}
+			factory.query.Text = "insert into authors (name, status) values ($1, $2) returning id, name, status"
+			factory.query.Name = "CreateAuthors"
+			factory.query.Cmd = ":batchone"
+			factory.query.Params = createParams
+			factory.query.Comments = []string{"gql: Mutation.createAuthors"}
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries,
+				&plugin.Query{
+					Text:     "insert into authors (name, status) values ($1, $2)",
+					Name:     "CopyAuthors",
+					Cmd:      ":copyfrom",
+					Params:   createParams,
+					Comments: []string{"gql: Mutation.copyAuthors"},
+					Filename: "authors.sql",
+				},
+				&plugin.Query{
+					Text:     "delete from authors where id = $1",
+					Name:     "DeleteAuthors",
+					Cmd:      ":batchexec",
+					Params:   []*plugin.Parameter{{Number: 1, Column: factory.columns[0]}},
+					Comments: []string{"gql: Mutation.deleteAuthors"},
+					Filename: "authors.sql",
+				},
+				&plugin.Query{
+					Text:     "select id, name, status from authors where status = $1",
+					Name:     "GetAuthorsByStatuses",
+					Cmd:      ":batchmany",
+					Columns:  factory.columns,
+					Params:   []*plugin.Parameter{{Number: 1, Column: factory.columns[2]}},
+					Comments: []string{"gql: Query.authorsByStatuses"},
+					Filename: "authors.sql",
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the batch and copyfrom queries are passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the queries should take the lists of the params")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), "createAuthors(request: [CreateAuthorsInput!]!): [Author!]!")
+					require.Contains(t, string(file.Contents), "copyAuthors(request: [CopyAuthorsInput!]!): Int!")
+					require.Contains(t, string(file.Contents), "deleteAuthors(id: [UUID!]!): Boolean!")
+					require.Contains(t, string(file.Contents), "authorsByStatuses(status: [Status!]!): [[Author!]!]!")
+				case "delegate/authors.sql.go":
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Generate the exec result payloads", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.query.Text = "insert into authors (name, status) values ($1, $2)"
+			factory.query.Name = "CreateAuthor"
+			factory.query.Cmd = ":execlastid"
+			factory.query.Columns = nil
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[1]},
+				{Number: 2, Column: factory.columns[2]},
+			}
+			factory.query.Comments = []string{"gql: Mutation.createAuthor"}
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries,
+				&plugin.Query{
+					Text:     "delete from authors where status = $1",
+					Name:     "DeleteAuthors",
+					Cmd:      ":execresult",
+					Params:   []*plugin.Parameter{{Number: 1, Column: factory.columns[2]}},
+					Comments: []string{"gql: Mutation.deleteAuthors"},
+					Filename: "authors.sql",
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the :execlastid and :execresult queries are passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the fields should return the id and the ExecResult payload")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), "createAuthor(request: CreateAuthorInput!): ID!")
+					require.Contains(t, string(file.Contents), "deleteAuthors(status: Status!): ExecResult!")
+				case "common.graphql":
+					require.Contains(t, string(file.Contents), "type ExecResult")
+					continue
+				case "delegate/authors.sql.go":
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Declare the used scalars", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the table with the UUID column is passed to the generator")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the common schema should declare the used scalars only")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				if file.Name != "common.graphql" {
+					continue
+				}
+				require.Contains(t, string(file.Contents), "scalar UUID")
+				require.NotContains(t, string(file.Contents), "scalar JSON")
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Bind the pgx v5 types with the generated marshalers", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.MarshalPackage = "authors/graph/marshal"
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			table := factory.catalog.Schemas[0].Tables[0]
+			table.Columns = append(
+				table.Columns,
+				&plugin.Column{
+					Name:  "created_at",
+					Table: table.Rel,
+					Type:  &plugin.Identifier{Name: "timestamptz"},
+				},
+				&plugin.Column{
+					Name:  "rank",
+					Table: table.Rel,
+					Type:  &plugin.Identifier{Name: "int2"},
+				},
+			)
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the nullable text, timestamptz and smallint columns and the pgx/v5 SQL package")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the scalars should be bound to the generated marshalers of the pgtype wrappers")
+			require.NotNil(t, resp)
+			names := make([]string, 0, len(resp.Files))
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				switch file.Name {
+				case "common.graphql":
+					require.Contains(t, string(file.Contents), `extend scalar String @goModel(models: ["github.com/99designs/gqlgen/graphql.String", "authors/graph/marshal.Text"])`)
+				case "marshal/pgtype.go":
+					t.Log("	And the smallint should be range-checked instead of being wrapped")
+					require.Contains(t, string(file.Contents), "value, err := schema.UnmarshalInt16(v)")
+				case "delegate/authors.sql.go":
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+			require.Contains(t, names, "marshal/pgtype.go")
+		},
+	)
+
+	t.Run(
+		"Map the 64-bit integers and the decimals to the scalars", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.MarshalPackage = "authors/graph/marshal"
+			factory.options.Int64Scalar = "BigInt"
+			factory.options.EmitDecimalScalar = true
+			table := factory.catalog.Schemas[0].Tables[0]
+			table.Columns = append(
+				table.Columns,
+				&plugin.Column{
+					Name:    "views",
+					NotNull: true,
+					Table:   table.Rel,
+					Type:    &plugin.Identifier{Name: "bigserial"},
+				},
+				&plugin.Column{
+					Name:  "followers",
+					Table: table.Rel,
+					Type:  &plugin.Identifier{Name: "int8"},
+				},
+				&plugin.Column{
+					Name:  "balance",
+					Table: table.Rel,
+					Type:  &plugin.Identifier{Schema: "pg_catalog", Name: "numeric"},
+				},
+			)
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the bigserial, int8 and numeric columns")
+			t.Log("When the generator is called with the BigInt and Decimal scalars")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the columns should be mapped to the scalars bound to the marshalers keeping the precision")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), "views: BigInt!\n")
+					require.Contains(t, string(file.Contents), "followers: BigInt\n")
+					require.Contains(t, string(file.Contents), "balance: Decimal\n")
+				case "common.graphql":
+					require.Contains(
+						t,
+						string(file.Contents),
+						`scalar BigInt @goModel(models: ["github.com/debugger84/sqlc-graphql/schema.BigInt", "github.com/debugger84/sqlc-graphql/schema.NullBigInt", "authors/graph/marshal.Int8"])`,
+					)
+					require.Contains(
+						t,
+						string(file.Contents),
+						`scalar Decimal @goModel(models: ["github.com/debugger84/sqlc-graphql/schema.Decimal", "github.com/debugger84/sqlc-graphql/schema.NullDecimal", "authors/graph/marshal.Numeric"])`,
+					)
+					continue
+				case "marshal/pgtype.go":
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Bind the pgx v5 intervals and times of day to String", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.MarshalPackage = "authors/graph/marshal"
+			table := factory.catalog.Schemas[0].Tables[0]
+			table.Columns = append(
+				table.Columns,
+				&plugin.Column{
+					Name:  "break_time",
+					Table: table.Rel,
+					Type:  &plugin.Identifier{Schema: "pg_catalog", Name: "interval"},
+				},
+				&plugin.Column{
+					Name:    "starts_at",
+					NotNull: true,
+					Table:   table.Rel,
+					Type:    &plugin.Identifier{Schema: "pg_catalog", Name: "time"},
+				},
+			)
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the interval and time columns and the pgx/v5 SQL package")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the columns should be mapped to String bound to the marshalers of the text representation")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), "breakTime: String\n")
+					require.Contains(t, string(file.Contents), "startsAt: String!\n")
+					require.NotContains(t, string(file.Contents), "Interval")
+				case "common.graphql":
+					require.Contains(
+						t,
+						string(file.Contents),
+						`extend scalar String @goModel(models: ["github.com/99designs/gqlgen/graphql.String", "authors/graph/marshal.Text", "authors/graph/marshal.Interval", "authors/graph/marshal.Time"])`,
+					)
+					continue
+				case "marshal/pgtype.go":
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Bind the pgx v4 ranges to String", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			factory.options.SqlPackage = "pgx/v4"
+			factory.options.MarshalPackage = "authors/graph/marshal"
+			table := factory.catalog.Schemas[0].Tables[0]
+			table.Columns = append(
+				table.Columns,
+				&plugin.Column{
+					Name:  "active",
+					Table: table.Rel,
+					Type:  &plugin.Identifier{Name: "daterange"},
+				},
+			)
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the daterange column and the pgx/v4 SQL package")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the column should be mapped to String bound to the marshaler of pgtype.Daterange")
+			require.NotNil(t, resp)
+			names := make([]string, 0, len(resp.Files))
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), "active: String\n")
+				case "marshal/pgtype.go":
+					require.Contains(t, string(file.Contents), "func UnmarshalDaterange(v any) (res pgtype.Daterange, err error) {")
+				}
+			}
+			require.Contains(t, names, "marshal/pgtype.go")
+		},
+	)
+
+	t.Run(
+		"Fail on the unknown 64-bit integer scalar", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Int64Scalar = "Long"
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the int64_scalar option that is neither Int64 nor BigInt")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(t, err, `invalid options: int64_scalar must be Int64 or BigInt, got "Long"`)
+		},
+	)
+
+	t.Run(
+		"Implement the Relay Node interface", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.EmitNodeInterface = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the table with the id column and the query fetching its row by the id")
+			t.Log("When the generator is called with the Node interface")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the type should implement Node with the global id and keep the key in rowId")
+			t.Log("	And the node field should fetch the row by the query")
+			require.NotNil(t, resp)
+			names := make([]string, 0, len(resp.Files))
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), `type Author implements Node @goModel(model: "authors/storage.Author")`)
+					require.Contains(t, string(file.Contents), "node(id: ID!): Node")
+				case "delegate/node.go":
+					require.Contains(t, string(file.Contents), "return d.Queries.GetAuthor(ctx, k)")
+				case "delegate/delegate.go":
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+			require.Contains(t, names, "delegate/node.go")
+		},
+	)
+
+	t.Run(
+		"Implement the Relay Node interface by the key of the :one query", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.EmitNodeInterface = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.columns[0].Name = "author_id"
+			factory.query.Text = "select author_id, name, status from authors where author_id = $1"
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the table without the id column and the query fetching its row by the author_id")
+			t.Log("When the generator is called with the Node interface")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the type should implement Node with the global id made of the author_id kept in its field")
+			require.NotNil(t, resp)
+			names := make([]string, 0, len(resp.Files))
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), `type Author implements Node @goModel(model: "authors/storage.Author")`)
+					require.Contains(t, string(file.Contents), "authorId: UUID!")
+					require.NotContains(t, string(file.Contents), "rowId")
+				case "delegate/node.go":
+					require.Contains(t, string(file.Contents), `return schema.EncodeGlobalID("Author", obj.AuthorID)`)
+					require.Contains(t, string(file.Contents), "return d.Queries.GetAuthor(ctx, k)")
+				}
+			}
+			require.Contains(t, names, "delegate/node.go")
+		},
+	)
+
+	t.Run(
+		"Implement the Relay Node interface by the configured primary key", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.EmitNodeInterface = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.options.PrimaryKeys = []string{"authors.author_id"}
+			factory.columns[0].Name = "author_id"
+			factory.query.Text = "select author_id, name, status from authors where name = $1"
+			factory.query.Params = []*plugin.Parameter{{Number: 1, Column: factory.columns[1]}}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the primary key of the table and the query fetching its row by another column")
+			t.Log("When the generator is called with the Node interface")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the global id should be made of the primary key, not of the column of the query")
+			require.NotNil(t, resp)
+			for _, file := range resp.Files {
+				if file.Name == "delegate/node.go" {
+					require.Contains(t, string(file.Contents), `return schema.EncodeGlobalID("Author", obj.AuthorID)`)
+					require.NotContains(t, string(file.Contents), "GetAuthor")
+				}
+			}
+
+			factory.options.PrimaryKeys = []string{"authors.uuid"}
+			_, err = golang.Generate(ctx, factory.GenerateRequest())
+
+			t.Log("	And the generator should fail on the primary key that is not a column of the table")
+			require.EqualError(t, err, "primary key authors.uuid: column uuid is not found in the table authors")
+		},
+	)
+
+	t.Run(
+		"Make the schema a federation subgraph", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Federation = true
+			factory.options.GenCommonParts = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			table := factory.catalog.Schemas[0].Tables[0]
+			postID := &plugin.Column{
+				Name:    "post_id",
+				NotNull: false,
+				Table:   table.Rel,
+				Type:    &plugin.Identifier{Name: "uuid"},
+			}
+			table.Columns = append(table.Columns, postID)
+			factory.query.Text = "select id, name, status, post_id from authors where id = $1"
+			factory.query.Columns = table.Columns
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries, &plugin.Query{
+					Text:     "select id, name, status, post_id from authors where post_id = $1",
+					Name:     "ListPostAuthors",
+					Cmd:      ":many",
+					Filename: "authors.sql",
+					Columns:  table.Columns,
+					Params:   []*plugin.Parameter{{Number: 1, Column: postID}},
+					Comments: []string{"gql: Post.authors"},
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the table with the id column and the query extending the Post type of another subgraph")
+			t.Log("When the generator is called with the federation")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the type of the table should be the entity with the id key")
+			t.Log("	And the Post type should be the stub with the external key")
+			t.Log("	And the _entities field should fetch the entities by the query")
+			require.NotNil(t, resp)
+			names := make([]string, 0, len(resp.Files))
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), `type Author @goModel(model: "authors/storage.Author") @key(fields: "id")`)
+					require.Contains(t, string(file.Contents), "id: UUID! @external")
+					require.Contains(t, string(file.Contents), "union _Entity")
+				case "common.graphql":
+					require.Contains(t, string(file.Contents), "directive @key(fields: FieldSet!")
+					require.Contains(t, string(file.Contents), "_service: _Service!")
+				case "delegate/entity.go":
+					require.Contains(t, string(file.Contents), "row, err := d.Queries.GetAuthor(ctx, k)")
+					require.Contains(t, string(file.Contents), "res[i] = &PostReference{ID: k}")
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+			require.Contains(t, names, "delegate/entity.go")
+		},
+	)
+
+	t.Run(
+		"Key the federation entities by the primary keys and the stubs by the configured fields", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Federation = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.options.FederationStubs = []opts.FederationStub{{Type: "Post", Key: "sku"}}
+			table := factory.catalog.Schemas[0].Tables[0]
+			factory.columns[0].Name = "author_id"
+			postSku := &plugin.Column{
+				Name:    "post_sku",
+				NotNull: false,
+				Table:   table.Rel,
+				Type:    &plugin.Identifier{Name: "text"},
+			}
+			table.Columns = append(table.Columns, postSku)
+			factory.query.Text = "select author_id, name, status, post_sku from authors where author_id = $1"
+			factory.query.Columns = table.Columns
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries, &plugin.Query{
+					Text:     "select author_id, name, status, post_sku from authors where post_sku = $1",
+					Name:     "ListPostAuthors",
+					Cmd:      ":many",
+					Filename: "authors.sql",
+					Columns:  table.Columns,
+					Params:   []*plugin.Parameter{{Number: 1, Column: postSku}},
+					Comments: []string{"gql: Post.authors"},
+				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the table keyed by the author_id and the query extending the Post type keyed by the sku")
+			t.Log("When the generator is called with the federation")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the entity should be keyed by the authorId")
+			t.Log("	And the stub should be keyed by the sku of the type of the post_sku column")
+			require.NotNil(t, resp)
+			names := make([]string, 0, len(resp.Files))
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), `type Author @goModel(model: "authors/storage.Author") @key(fields: "authorId")`)
+					require.Contains(t, string(file.Contents), `@key(fields: "sku")`)
+					require.Contains(t, string(file.Contents), "sku: String! @external")
+				case "delegate/entity.go":
+					require.Contains(t, string(file.Contents), `schema.EntityKey[uuid.UUID](r, "authorId")`)
+					require.Contains(t, string(file.Contents), "row, err := d.Queries.GetAuthor(ctx, k)")
+					require.Contains(t, string(file.Contents), `schema.EntityKey[string](r, "sku")`)
+					require.Contains(t, string(file.Contents), "res[i] = &PostReference{Sku: k}")
+				}
+			}
+			require.Contains(t, names, "delegate/entity.go")
+		},
+	)
+
+	t.Run(
+		"Fetch the federation entities by the batch query once per type", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.Federation = true
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.query.Name = "GetAuthors"
+			factory.query.Cmd = ":batchone"
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the table with the id column and the :batchone query fetching the authors by the ids")
+			t.Log("When the generator is called with the federation")
 			t.Log("	Then the generator should return a response without an error")
 			require.NoError(t, err)
-			t.Log("	And the response should contain the generated code")
+			t.Log("	And the _entities field should collect the keys of the authors")
+			t.Log("	And the batch query should be called once with all the keys")
+			t.Log("	And the fetched authors should be put at the positions of their representations")
 			require.NotNil(t, resp)
-			require.Len(t, resp.Files, 2)
-			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
-			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+			names := make([]string, 0, len(resp.Files))
+			for _, file := range resp.Files {
+				names = append(names, file.Name)
+				if file.Name != "delegate/entity.go" {
+					continue
+				}
+				content := string(file.Contents)
+				require.Contains(t, content, "authorKeys = append(authorKeys, k)")
+				require.Contains(t, content, "authorPositions = append(authorPositions, i)")
+				require.Equal(t, 1, strings.Count(content, "d.Queries.GetAuthors(ctx, authorKeys)"))
+				require.Contains(t, content, "res[authorPositions[j]] = &item")
+				require.NotContains(t, content, "[]uuid.UUID{k}")
+				snaps.WithConfig(snaps.Ext(".go")).MatchStandaloneSnapshot(t, content)
+			}
+			require.Contains(t, names, "delegate/entity.go")
 		},
 	)
 
 	t.Run(
-		"Exclude field", func(t *testing.T) {
+		"Generate the subscription re-running the notified query", func(t *testing.T) {
 			factory := NewGenReqFactory()
-			factory.options.Exclude = []string{"Author.name"}
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			factory.query.Comments = []string{"gql: Subscription.authorUpdated", "gql-notify: author_updated"}
 			req := factory.GenerateRequest()
 
 			resp, err := golang.Generate(ctx, req)
 
-			t.Log("Given the option to exclude the 'name' field is passed to the generator")
+			t.Log("Given the query fetching the author by the id with the gql-notify comment")
 			t.Log("When the generator is called")
 			t.Log("	Then the generator should return a response without an error")
 			require.NoError(t, err)
-			t.Log("	And the response should not contain the name field in the generated Author type")
+			t.Log("	And the subscription field should have no arguments")
+			t.Log("	And the resolver should re-run the query for the notified keys")
 			require.NotNil(t, resp)
-			require.Len(t, resp.Files, 2)
+			names := make([]string, 0, len(resp.Files))
 			for _, file := range resp.Files {
-				if file.Name == "schema.graphql" {
-					snaps.WithConfig(snaps.Ext("."+file.Name)).
-						MatchStandaloneSnapshot(t, string(file.Contents))
-					require.NotContains(t, string(file.Contents), "name:")
+				names = append(names, file.Name)
+				switch file.Name {
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), "authorUpdated: Author!")
+				case "delegate/authors.sql.go":
+					require.Contains(
+						t, string(file.Contents),
+						`return schema.Subscribe(ctx, d.Notifier, "author_updated", d.Queries.GetAuthor)`,
+					)
+				case "delegate/delegate.go":
+					require.Contains(t, string(file.Contents), "Notifier schema.Notifier")
+				default:
+					continue
 				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
 			}
+			require.Contains(t, names, "delegate/authors.sql.go")
 		},
 	)
 
 	t.Run(
-		"Add directive to query", func(t *testing.T) {
+		"Fail on the notified query of the Query field", func(t *testing.T) {
 			factory := NewGenReqFactory()
-			factory.options.Directives = []opts.Directive{
-				{
-					Model:     "Query",
-					Field:     "author",
-					Directive: "authGuard",
-				},
-				{
-					Model:     "Author",
-					Field:     "name",
-					Directive: "authGuard",
-				},
+			factory.query.Comments = []string{"gql: Query.author", "gql-notify: author_updated"}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the query of the Query field with the gql-notify comment")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(t, err, "authors.sql: query GetAuthor: gql-notify requires the Subscription field")
+		},
+	)
+
+	t.Run(
+		"Deprecate the fields, the query and the enum values", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.columns[1].Comment = "The name of the author\n@deprecated: use fullName"
+			factory.query.Comments = []string{"gql: Query.author", "gql-deprecated: use authorV2"}
+			factory.options.Deprecated = map[string]string{
+				"Author.status":   "",
+				"Status.inactive": "use active",
 			}
 			req := factory.GenerateRequest()
 
 			resp, err := golang.Generate(ctx, req)
 
-			t.Log("Given the options with adding directives to the query and the Author type are passed to the generator")
+			t.Log("Given the column comment with the @deprecated marker, the query with the gql-deprecated comment")
+			t.Log("	And the deprecated option with the field and the enum value")
 			t.Log("When the generator is called")
 			t.Log("	Then the generator should return a response without an error")
 			require.NoError(t, err)
-			t.Log("	And the response should contain authGuard directive in the generated code")
-			require.NotNil(t, resp)
-			require.Len(t, resp.Files, 2)
-			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
-			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+			t.Log("	And all of them should be marked with the @deprecated directive")
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), `name: String @deprecated(reason: "use fullName")`)
+					require.Contains(t, string(file.Contents), `status: Status! @deprecated(reason: "No longer supported")`)
+					require.Contains(t, string(file.Contents), `inactive @deprecated(reason: "use active")`)
+					require.NotContains(t, string(file.Contents), "@deprecated: use fullName")
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), `@deprecated(reason: "use authorV2")`)
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
 
+	t.Run(
+		"Escape the deprecation reasons as the GraphQL strings", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.columns[1].Comment = "The name of the author\n@deprecatedSince: 2024"
+			factory.query.Comments = []string{"gql: Query.author", "gql-deprecatedQuery: use authorV2"}
+			reason := "use \"state\" \\ the \x07bell, \v, \u00e9 and \U0001F600"
+			factory.options.Deprecated = map[string]string{"Author.status": reason}
+			req := factory.GenerateRequest()
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the deprecation reason with the quotes, the backslash, the control and the non-BMP characters")
+			t.Log("	And the comments starting with the gql-deprecated and @deprecated prefixes but not being the markers")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			var names []string
 			for _, file := range resp.Files {
-				if file.Name == "schema.graphql" {
-					require.Contains(
-						t,
-						string(file.Contents),
-						"name: String @authGuard",
-						"The name field of Author type should contain authGuard directive",
-					)
-				} else {
-					require.Contains(
-						t,
-						string(file.Contents),
-						"author(id: UUID!): Author! @authGuard",
-						"The author query should contain authGuard directive",
-					)
+				names = append(names, file.Name)
+				switch file.Name {
+				case "schema.graphql":
+					t.Log("	And the reason should be parsed by the GraphQL parser as is")
+					doc, err := parser.ParseSchema(&ast.Source{Name: file.Name, Input: string(file.Contents)})
+					require.NoError(t, err)
+					author := doc.Definitions.ForName("Author")
+					require.NotNil(t, author)
+					directive := author.Fields.ForName("status").Directives.ForName("deprecated")
+					require.NotNil(t, directive)
+					require.Equal(t, reason, directive.Arguments.ForName("reason").Value.Raw)
+					t.Log("	And the fields should not be deprecated by the comments that are not the markers")
+					require.Nil(t, author.Fields.ForName("name").Directives.ForName("deprecated"))
+				case "authors.graphql":
+					require.NotContains(t, string(file.Contents), "@deprecated")
 				}
 			}
+			require.Contains(t, names, "schema.graphql")
+			require.Contains(t, names, "authors.graphql")
 		},
 	)
+
 	t.Run(
-		"Overwrite SQL type by custom Gql type", func(t *testing.T) {
+		"Deprecate the nullable arguments and input fields", func(t *testing.T) {
 			factory := NewGenReqFactory()
-			factory.options.Overrides = []opts.Override{
-				{
-					DBType:  "text",
-					GqlType: "MyText",
-				},
-				{
-					DBType:  "public.status",
-					GqlType: "MyStatus",
+			factory.query.Text = "select id, name, status from authors where status = $1 and name = $2"
+			factory.query.Name = "ListAuthors"
+			factory.query.Cmd = ":many"
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[2]},
+				{Number: 2, Column: factory.columns[1]},
+			}
+			factory.query.Comments = []string{"gql: Query.authors"}
+			factory.options.Deprecated = map[string]string{
+				"AuthorsInput.name":       "use the filter",
+				"AuthorsByNameInput.name": "",
+			}
+			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries, &plugin.Query{
+					Text:     "select id, name, status from authors where name = $1",
+					Name:     "ListAuthorsByName",
+					Cmd:      ":many",
+					Columns:  factory.columns,
+					Params:   []*plugin.Parameter{{Number: 1, Column: factory.columns[1]}},
+					Filename: "authors.sql",
+					Comments: []string{"gql: Query.authorsByName"},
 				},
+			)
+
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the deprecated option with the nullable input field and the nullable single argument")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the input field and the argument should be marked with the @deprecated directive")
+			for _, file := range resp.Files {
+				if file.Name != "authors.graphql" {
+					continue
+				}
+				require.Contains(t, string(file.Contents), `name: String @deprecated(reason: "use the filter")`)
+				require.Contains(t, string(file.Contents), `authorsByName(name: String @deprecated(reason: "No longer supported"))`)
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
 			}
+		},
+	)
+
+	t.Run(
+		"Fail on the deprecation of the required argument", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Comments = []string{"gql: Query.author"}
+			factory.options.Deprecated = map[string]string{"AuthorInput.id": "use the slug"}
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the deprecated option with the non-null argument of the query")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error, as the spec forbids deprecating the required arguments")
+			require.EqualError(
+				t, err,
+				"authors.sql: query GetAuthor: the deprecated AuthorInput.id is required, "+
+					"only the nullable arguments and input fields can be deprecated",
+			)
+		},
+	)
+
+	t.Run(
+		"Create the schema lockfile", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.SchemaLock = filepath.Join(t.TempDir(), "schema.lock.graphql")
+			factory.query.Comments = []string{"gql: Query.author"}
 			req := factory.GenerateRequest()
 
 			resp, err := golang.Generate(ctx, req)
 
-			t.Log("Given the options with adding directives to the query and the Author type are passed to the generator")
+			t.Log("Given the schema_lock option pointing to the missing file")
 			t.Log("When the generator is called")
 			t.Log("	Then the generator should return a response without an error")
 			require.NoError(t, err)
-			t.Log("	And the response should contain authGuard directive in the generated code")
-			require.NotNil(t, resp)
-			require.Len(t, resp.Files, 2)
-			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
-			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+			t.Log("	And the lockfile should be generated with the schema with the extensions merged into the types")
+			lock := findFile(t, resp, "schema.lock.graphql")
+			snaps.WithConfig(snaps.Ext(".schema.lock.graphql")).
+				MatchStandaloneSnapshot(t, string(lock))
+			t.Log("	And the lockfile should be written by sqlc, not by the generator")
+			require.NoFileExists(t, factory.options.SchemaLock)
+		},
+	)
+
+	t.Run(
+		"Fail on the breaking change of the locked schema", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.SchemaLock = filepath.Join(t.TempDir(), "schema.lock.graphql")
+			factory.options.SchemaLockOut = "../graph/schema.lock.graphql"
+			factory.query.Comments = []string{"gql: Query.author"}
+			resp, err := golang.Generate(ctx, factory.GenerateRequest())
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(factory.options.SchemaLock, findFile(t, resp, "../graph/schema.lock.graphql"), 0o644))
+			factory.catalog.Schemas[0].Enums[0].Vals = []string{"active", "banned"}
+
+			_, err = golang.Generate(ctx, factory.GenerateRequest())
+
+			t.Log("Given the locked schema")
+			t.Log("When the value of the enum is replaced with another one")
+			t.Log("	Then the generator should return an error with the removed value")
+			require.EqualError(
+				t, err,
+				"schema lock: the breaking changes are not accepted in accept_breaking_changes: "+
+					"Status.inactive: the enum value is removed",
+			)
+
+			factory.options.AcceptBreakingChanges = []string{"Status.inactive"}
+			resp, err = golang.Generate(ctx, factory.GenerateRequest())
+
+			t.Log("When the removal of the value is accepted")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the generated lockfile should contain the new value")
+			lock := findFile(t, resp, "../graph/schema.lock.graphql")
+			require.Contains(t, string(lock), "banned")
+			require.NotContains(t, string(lock), "inactive")
+		},
+	)
+
+	t.Run(
+		"Fail on the lockfile generated to another file than the compared one", func(t *testing.T) {
+			dir := t.TempDir()
+			factory := NewGenReqFactory()
+			factory.options.SchemaLock = filepath.Join(dir, "graph", "schema.lock.graphql")
+			factory.options.SchemaLockOut = "../graph/schema.lock.graphql"
+			factory.query.Comments = []string{"gql: Query.author"}
+			req := factory.GenerateRequest()
+			req.Settings.Codegen = &plugin.Codegen{Out: filepath.Join(dir, "storage")}
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the schema_lock_out option pointing to the schema_lock file from the out directory")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+
+			factory.options.SchemaLockOut = ""
+			req = factory.GenerateRequest()
+			req.Settings.Codegen = &plugin.Codegen{Out: filepath.Join(dir, "storage")}
+
+			_, err = golang.Generate(ctx, req)
+
+			t.Log("When the lockfile is generated to the out directory by default")
+			t.Log("	Then the generator should return an error with both paths")
+			require.EqualError(
+				t, err,
+				fmt.Sprintf(
+					"schema lock: schema_lock_out schema.lock.graphql resolves to %s, but schema_lock is %s, "+
+						"they should be the same file",
+					filepath.Join(dir, "storage", "schema.lock.graphql"),
+					filepath.Join(dir, "graph", "schema.lock.graphql"),
+				),
+			)
+		},
+	)
+
+	t.Run(
+		"Bind the bounds of the int8range to the int64 scalar", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.options.GenCommonParts = true
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.Int64Scalar = "Int64"
+			table := factory.catalog.Schemas[0].Tables[0]
+			table.Columns = append(
+				table.Columns, &plugin.Column{
+					Name:    "pages",
+					NotNull: true,
+					Table:   table.Rel,
+					Type:    &plugin.Identifier{Name: "int8range"},
+				},
+			)
+			req := factory.GenerateRequest()
 
+			resp, err := golang.Generate(ctx, req)
+
+			t.Log("Given the int8range column and the Int64 scalar of the 64-bit integers")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return a response without an error")
+			require.NoError(t, err)
+			t.Log("	And the bounds of the BigIntRange should be of the Int64 scalar")
 			for _, file := range resp.Files {
-				if file.Name == "schema.graphql" {
-					require.Contains(
-						t,
-						string(file.Contents),
-						"name: MyText",
-						"The name field should be of type MyText",
-					)
-					require.Contains(
-						t,
-						string(file.Contents),
-						"status: MyStatus",
-						"The status field should be of type MyStatus",
-					)
-				} else {
-					require.Contains(
-						t,
-						string(file.Contents),
-						"author(id: UUID!): Author!",
-						"The author query should return Author",
-					)
+				if file.Name != "common.graphql" {
+					continue
 				}
+				require.Contains(t, string(file.Contents), "scalar Int64 ")
+				require.Contains(t, string(file.Contents), "type BigIntRange")
+				require.Contains(t, string(file.Contents), "    lower: Int64\n")
+				require.NotContains(t, string(file.Contents), "lower: Int\n")
+				snaps.WithConfig(snaps.Ext("."+file.Name)).
+					MatchStandaloneSnapshot(t, string(file.Contents))
 			}
 		},
 	)
+
 	t.Run(
-		"Overwrite Gql type of a column", func(t *testing.T) {
+		"Generate the range types", func(t *testing.T) {
 			factory := NewGenReqFactory()
-			factory.options.Overrides = []opts.Override{
-				{
-					Column:  "authors.name",
-					GqlType: "MyText",
-				},
+			factory.options.GenCommonParts = true
+			factory.options.SqlPackage = "pgx/v5"
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			table := factory.catalog.Schemas[0].Tables[0]
+			during := &plugin.Column{
+				Name:    "during",
+				NotNull: true,
+				Table:   table.Rel,
+				Type:    &plugin.Identifier{Name: "tstzrange"},
 			}
+			slots := &plugin.Column{
+				Name:  "slots",
+				Table: table.Rel,
+				Type:  &plugin.Identifier{Name: "int4multirange"},
+			}
+			table.Columns = append(table.Columns, during, slots)
+			factory.query.Text = "update authors set during = $2, slots = $3 where id = $1"
+			factory.query.Name = "UpdateAuthorSchedule"
+			factory.query.Cmd = ":exec"
+			factory.query.Columns = nil
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: factory.columns[0]},
+				{Number: 2, Column: during},
+				{Number: 3, Column: slots},
+			}
+			factory.query.Comments = []string{"gql: Mutation.updateAuthorSchedule"}
 			req := factory.GenerateRequest()
+			req.Queries = append(
+				req.Queries, &plugin.Query{
+					Text:     "select id, name from authors where during && $1",
+					Name:     "ListAuthorsDuring",
+					Cmd:      ":many",
+					Filename: "authors.sql",
+					Columns:  factory.columns[:2],
+					Params:   []*plugin.Parameter{{Number: 1, Column: during}},
+					Comments: []string{"gql: Query.authorsDuring"},
+				},
+				&plugin.Query{
+					Text:     "select slots from authors where id = $1",
+					Name:     "GetAuthorSlots",
+					Cmd:      ":one",
+					Filename: "authors.sql",
+					Columns:  []*plugin.Column{slots},
+					Params:   []*plugin.Parameter{{Number: 1, Column: factory.columns[0]}},
+					Comments: []string{"gql: Query.authorSlots"},
+				},
+			)
 
 			resp, err := golang.Generate(ctx, req)
 
-			t.Log("Given the options with adding directives to the query and the Author type are passed to the generator")
+			t.Log("Given the tstzrange and int4multirange columns and the pgx/v5 SQL package")
 			t.Log("When the generator is called")
 			t.Log("	Then the generator should return a response without an error")
 			require.NoError(t, err)
-			t.Log("	And the response should contain authGuard directive in the generated code")
+			t.Log("	And the ranges should be the objects in the types and the inputs in the arguments")
+			t.Log("	And the delegates should convert the ranges of the fields, the arguments and the results by the adapters")
 			require.NotNil(t, resp)
-			require.Len(t, resp.Files, 2)
-			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
-			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
-
+			names := make([]string, 0, len(resp.Files))
 			for _, file := range resp.Files {
-				if file.Name == "schema.graphql" {
-					require.Contains(
-						t,
-						string(file.Contents),
-						"name: MyText",
-						"The name field should be of type MyText",
-					)
+				names = append(names, file.Name)
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), "during: TimestampRange! @goField(forceResolver: true)")
+					require.Contains(t, string(file.Contents), "slots: [IntRange!] @goField(forceResolver: true)")
+				case "authors.graphql":
+					require.Contains(t, string(file.Contents), "authorsDuring(during: TimestampRangeInput!)")
+				case "common.graphql":
+					require.Contains(t, string(file.Contents), "input TimestampRangeInput")
+					require.Contains(t, string(file.Contents), "type IntRange")
+					require.NotContains(t, string(file.Contents), "DateRange")
+				case "delegate/authors.sql.go":
+					require.Contains(t, string(file.Contents), "duringValue, err := during.Timestamptz()")
+					require.Contains(t, string(file.Contents), "return d.Queries.ListAuthorsDuring(ctx, duringValue)")
+					require.Contains(t, string(file.Contents), "return schema.Ranges(row, schema.NewInt4Range)")
+				case "delegate/delegate.go":
+					require.Contains(t, string(file.Contents), "type AuthorDelegate struct")
+					require.Contains(t, string(file.Contents), "type UpdateAuthorScheduleInputDelegate struct")
+					continue
+				case "delegate/range.go":
+					require.Contains(t, string(file.Contents), "return schema.NewTimestamptzRange(obj.During)")
+					require.Contains(t, string(file.Contents), "obj.Slots, err = schema.Multirange(data, (*schema.IntRange).Int4)")
+				default:
+					continue
 				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
 			}
+			require.Contains(t, names, "delegate/range.go")
 		},
 	)
 
 	t.Run(
-		"Generate mutation", func(t *testing.T) {
-
+		"Generate array columns as lists", func(t *testing.T) {
 			factory := NewGenReqFactory()
-			factory.query.Text = "insert into authors (id, name, status) values ($1, $2, $3) returning id, name, status"
-			factory.query.Name = "InsertAuthor"
-			factory.query.Cmd = ":exec"
-			factory.query.Comments = []string{
-				"gql: Mutation.createAuthor",
+			factory.options.ResolverPackage = "authors/graph/delegate"
+			table := factory.catalog.Schemas[0].Tables[0]
+			tags := &plugin.Column{
+				Name:      "tags",
+				Table:     table.Rel,
+				IsArray:   true,
+				ArrayDims: 1,
+				Type:      &plugin.Identifier{Name: "text"},
 			}
-
+			scores := &plugin.Column{
+				Name:      "scores",
+				NotNull:   true,
+				Table:     table.Rel,
+				IsArray:   true,
+				ArrayDims: 2,
+				Type:      &plugin.Identifier{Name: "int4"},
+			}
+			statuses := &plugin.Column{
+				Name:      "statuses",
+				NotNull:   true,
+				Table:     table.Rel,
+				IsArray:   true,
+				ArrayDims: 1,
+				Type:      &plugin.Identifier{Name: "status", Schema: table.Rel.Schema},
+			}
+			table.Columns = append(table.Columns, tags, scores, statuses)
+			factory.query.Text = "select id, tags, scores, statuses from authors where statuses && $1 and tags && $2"
+			factory.query.Name = "ListTaggedAuthors"
+			factory.query.Cmd = ":many"
+			factory.query.Columns = []*plugin.Column{factory.columns[0], tags, scores, statuses}
+			factory.query.Params = []*plugin.Parameter{
+				{Number: 1, Column: statuses},
+				{Number: 2, Column: tags},
+			}
+			factory.query.Comments = []string{"gql: Query.taggedAuthors"}
 			req := factory.GenerateRequest()
+
 			resp, err := golang.Generate(ctx, req)
 
-			t.Log("Given the insert SQL query is passed to the generator")
+			t.Log("Given the text, two-dimensional integer and enum array columns")
 			t.Log("When the generator is called")
 			t.Log("	Then the generator should return a response without an error")
 			require.NoError(t, err)
-			t.Log("	And the response should contain the generated code")
+			t.Log("	And the array columns should be the lists in the fields and the arguments")
 			require.NotNil(t, resp)
-			require.Len(t, resp.Files, 2)
-			snaps.WithConfig(snaps.Ext("."+resp.Files[0].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[0].Contents))
-			snaps.WithConfig(snaps.Ext("."+resp.Files[1].Name)).
-				MatchStandaloneSnapshot(t, string(resp.Files[1].Contents))
+			for _, file := range resp.Files {
+				switch file.Name {
+				case "schema.graphql":
+					require.Contains(t, string(file.Contents), "tags: [String!]\n")
+					require.Contains(t, string(file.Contents), "scores: [[Int!]!]!\n")
+					require.Contains(t, string(file.Contents), "statuses: [Status!]!\n")
+				case "authors.graphql", "delegate/authors.sql.go":
+				default:
+					continue
+				}
+				snaps.WithConfig(snaps.Ext("."+path.Base(file.Name))).
+					MatchStandaloneSnapshot(t, string(file.Contents))
+			}
+		},
+	)
+
+	t.Run(
+		"Fail on the unsupported command", func(t *testing.T) {
+			factory := NewGenReqFactory()
+			factory.query.Cmd = ":unknown"
+			req := factory.GenerateRequest()
+
+			_, err := golang.Generate(ctx, req)
+
+			t.Log("Given the query with the command that cannot be turned into a GraphQL field")
+			t.Log("When the generator is called")
+			t.Log("	Then the generator should return an error")
+			require.EqualError(t, err, "authors.sql: query GetAuthor: the :unknown command is not supported in GraphQL")
 		},
 	)
 }
@@ -458,3 +2868,15 @@ func (f genReqFactory) GenerateRequest() *plugin.GenerateRequest {
 
 	return req
 }
+
+// findFile returns the contents of the generated file.
+func findFile(t *testing.T, resp *plugin.GenerateResponse, name string) []byte {
+	t.Helper()
+	for _, file := range resp.Files {
+		if file.Name == name {
+			return file.Contents
+		}
+	}
+	require.Failf(t, "the file is not generated", name)
+	return nil
+}
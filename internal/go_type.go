@@ -1,6 +1,14 @@
 package golang
 
 import (
+	"go/types"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/debugger84/sqlc-graphql/internal/opts"
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
 	"github.com/sqlc-dev/plugin-sdk-go/sdk"
@@ -33,3 +41,200 @@ func goInnerType(req *plugin.GenerateRequest, options *opts.Options, col *plugin
 		return "interface{}"
 	}
 }
+
+// goType returns the Go type of the column in the code generated by sqlc-gen-go.
+func goType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
+	// column overrides have the highest precedence
+	for _, override := range options.Overrides {
+		oride := override.ShimOverride
+		if oride.GoType.TypeName == "" || oride.Column == "" {
+			continue
+		}
+		cname := col.Name
+		if col.OriginalName != "" {
+			cname = col.OriginalName
+		}
+		if sdk.MatchString(oride.ColumnName, cname) && override.Matches(col.Table, req.Catalog.DefaultSchema) {
+			return oride.GoType.TypeName
+		}
+	}
+	typ := goInnerType(req, options, col)
+	switch {
+	case col.IsArray:
+		return strings.Repeat("[]", max(int(col.ArrayDims), 1)) + typ
+	case col.IsSqlcSlice:
+		return "[]" + typ
+	}
+	return typ
+}
+
+// Import paths of the packages used in the Go types generated by sqlc-gen-go
+var goTypePackages = map[string]string{
+	"json":   "encoding/json",
+	"net":    "net",
+	"netip":  "net/netip",
+	"pgtype": "github.com/jackc/pgx/v5/pgtype",
+	"pqtype": "github.com/sqlc-dev/pqtype",
+	"sql":    "database/sql",
+	"time":   "time",
+	"uuid":   "github.com/google/uuid",
+}
+
+// goImports collects the import paths used in a generated Go file.
+type goImports struct {
+	options *opts.Options
+	paths   map[string]struct{}
+	// models are the packages of the models, they are never grouped with the standard library
+	models map[string]struct{}
+}
+
+func newGoImports(options *opts.Options, paths ...string) *goImports {
+	i := &goImports{options: options, paths: map[string]struct{}{}, models: map[string]struct{}{}}
+	for _, p := range paths {
+		i.add(p)
+	}
+	return i
+}
+
+func (i *goImports) add(importPath string) {
+	i.paths[importPath] = struct{}{}
+}
+
+// Model returns the Go type of the model path, e.g. storage.Author for authors/storage.Author
+func (i *goImports) Model(modelPath string) string {
+	pos := strings.LastIndex(modelPath, ".")
+	importPath := modelPath[:pos]
+	i.add(importPath)
+	i.models[importPath] = struct{}{}
+	return path.Base(importPath) + modelPath[pos:]
+}
+
+// Type qualifies the Go type used in the package generated by sqlc-gen-go,
+// so it can be used in another package.
+func (i *goImports) Type(typ string) string {
+	prefix := ""
+	for {
+		switch {
+		case strings.HasPrefix(typ, "[]"):
+			prefix += "[]"
+			typ = typ[2:]
+			continue
+		case strings.HasPrefix(typ, "*"):
+			prefix += "*"
+			typ = typ[1:]
+			continue
+		}
+		break
+	}
+	if typ == "interface{}" || types.Universe.Lookup(typ) != nil {
+		return prefix + typ
+	}
+	pkg, _, qualified := strings.Cut(typ, ".")
+	if !qualified {
+		return prefix + i.Model(i.options.Package+"."+typ)
+	}
+	for _, override := range i.options.Overrides {
+		if override.GoTypeName == typ && override.GoImportPath != "" {
+			i.add(override.GoImportPath)
+			return prefix + typ
+		}
+	}
+	if pkg == "pgtype" && parseDriver(i.options.SqlPackage) == opts.SQLDriverPGXV4 {
+		i.add("github.com/jackc/pgtype")
+	} else if importPath, ok := goTypePackages[pkg]; ok {
+		i.add(importPath)
+	}
+	return prefix + typ
+}
+
+// Groups returns the standard library imports and the other imports sorted by path.
+func (i *goImports) Groups() [][]string {
+	var std, other []string
+	for p := range i.paths {
+		_, model := i.models[p]
+		if model || strings.Contains(strings.Split(p, "/")[0], ".") {
+			other = append(other, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+	var groups [][]string
+	for _, g := range [][]string{std, other} {
+		if len(g) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+var commonInitialisms = map[string]struct{}{
+	"API": {}, "CSS": {}, "HTML": {}, "HTTP": {}, "ID": {}, "IP": {},
+	"JSON": {}, "SQL": {}, "URI": {}, "URL": {}, "UUID": {}, "XML": {},
+}
+
+// goName converts the name of a GraphQL field to the Go name the way gqlgen does,
+// e.g. authorId becomes AuthorID.
+func goName(name string) string {
+	var words []string
+	start := 0
+	for pos, r := range name {
+		if pos > 0 && (r >= 'A' && r <= 'Z' || r == '_') {
+			words = append(words, name[start:pos])
+			start = pos
+		}
+	}
+	words = append(words, name[start:])
+
+	out := ""
+	for _, w := range words {
+		w = strings.TrimPrefix(w, "_")
+		if _, ok := commonInitialisms[strings.ToUpper(w)]; ok {
+			out += strings.ToUpper(w)
+		} else {
+			out += sdk.Title(w)
+		}
+	}
+	return out
+}
+
+var goFieldNamePattern = regexp.MustCompile(`@goField\([^)]*name:\s*"(\w+)"`)
+
+// goFieldName returns the name of the field in the struct generated by sqlc-gen-go.
+func goFieldName(f Field, options *opts.Options) string {
+	if m := goFieldNamePattern.FindStringSubmatch(f.Directive); m != nil {
+		return sdk.Title(m[1])
+	}
+	if rename := options.Rename[f.DBName]; rename != "" {
+		return rename
+	}
+	// a suffix is added to the repeated fields
+	suffix := strings.TrimPrefix(f.Name, StructName(f.DBName, options))
+
+	return goStructName(f.DBName) + suffix
+}
+
+// goStructName works as StructName, but keeps the Go initialism of "id" like sqlc-gen-go does.
+func goStructName(name string) string {
+	out := ""
+	name = strings.Map(
+		func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return '_'
+		}, name,
+	)
+	for _, p := range strings.Split(name, "_") {
+		if p == "id" {
+			out += "ID"
+		} else {
+			out += strings.Title(p)
+		}
+	}
+	if r, _ := utf8.DecodeRuneInString(out); unicode.IsDigit(r) {
+		return "_" + out
+	}
+	return out
+}
@@ -41,30 +41,50 @@ func gqlType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Col
 		}
 		if (oride.Column != "" && sdk.MatchString(oride.ColumnName, cname) && sameTable) ||
 			(col.Type != nil && col.Type.Name == typeName && col.Type.Schema == schema) {
-			tn := override.GqlType
-			if col.NotNull {
-				tn += "!"
-			}
-			if col.IsSqlcSlice {
-				tn = fmt.Sprintf("[%s]!", tn)
-			}
-
-			return tn
+			return gqlListType(override.GqlType, col)
 		}
 	}
-	typ := gqlInnerType(req, options, col)
-	if col.NotNull {
-		return typ + "!"
-	}
+	return gqlListType(gqlInnerType(req, options, col), col)
+}
 
-	if col.IsSqlcSlice {
+// gqlListType wraps the type of the column into the lists of the array dimensions.
+// The array elements and the inner lists are never null, the same way as in the Go code of sqlc-gen-go,
+// while the elements of sqlc.slice keep the nullability of the column.
+func gqlListType(typ string, col *plugin.Column) string {
+	switch {
+	case col.IsArray:
+		typ += "!"
+		for i := 1; i < int(col.ArrayDims); i++ {
+			typ = "[" + typ + "]!"
+		}
+		typ = "[" + typ + "]"
+		if col.NotNull {
+			typ += "!"
+		}
+		return typ
+	case col.IsSqlcSlice:
+		if col.NotNull {
+			typ += "!"
+		}
 		return fmt.Sprintf("[%s]!", typ)
+	case col.NotNull:
+		return typ + "!"
 	}
 	return typ
 }
 
 func gqlInnerType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
-	gotype := goInnerType(req, options, col)
+	// the pointers of emit_pointers_for_null_types are nullable the same way as the sql.Null types
+	gotype := strings.TrimPrefix(goInnerType(req, options, col), "*")
+	if options.EmitDecimalScalar && isDecimalColumn(col) {
+		return "Decimal"
+	}
+	if _, ok := int64Types[gotype]; ok && options.Int64Scalar != "" {
+		return options.Int64Scalar
+	}
+	if typ, ok := gqlRangeType(gotype); ok {
+		return typ
+	}
 	switch gotype {
 	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
 		return "Int"
@@ -79,7 +99,7 @@ func gqlInnerType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 	case "uuid.UUID", "uuid.NullUUID":
 		return "UUID"
 
-	case "netip.Addr", "*netip.Addr", "netip.Prefix", "*netip.Prefix", "net.HardwareAddr", "pgtype.Range[pgtype.Date]", "pgtype.Multirange[pgtype.Range[pgtype.Date]]":
+	case "netip.Addr", "*netip.Addr", "netip.Prefix", "*netip.Prefix", "net.HardwareAddr":
 		return "String"
 
 	case "sql.NullInt8", "sql.NullInt16", "sql.NullInt32", "sql.NullInt64", "sql.NullUint", "sql.NullUint8", "sql.NullUint16", "sql.NullUint32", "sql.NullUint64":
@@ -90,6 +110,22 @@ func gqlInnerType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		return "Boolean"
 	case "sql.NullString":
 		return "String"
+	case "pgtype.Int2", "pgtype.Int4", "pgtype.Int8":
+		return "Int"
+	case "pgtype.Float4", "pgtype.Float8":
+		return "Float"
+	case "pgtype.Bool":
+		return "Boolean"
+	case "pgtype.Text", "pgtype.Numeric", "pgtype.Inet", "pgtype.CIDR", "pgtype.Macaddr":
+		return "String"
+	// the wrappers without a matching scalar are written in the text representation of PostgreSQL
+	case "pgtype.Interval", "pgtype.Time",
+		"pgtype.Daterange", "pgtype.Tsrange", "pgtype.Tstzrange", "pgtype.Numrange", "pgtype.Int4range", "pgtype.Int8range":
+		return "String"
+	case "pgtype.Date", "pgtype.Timestamp", "pgtype.Timestamptz":
+		return "Time"
+	case "pgtype.UUID":
+		return "UUID"
 	case "[]byte", "pgtype.JSON", "pgtype.JSONB", "json.RawMessage", "pqtype.NullRawMessage":
 		return "JSON"
 	case "interface{}":
@@ -102,6 +138,30 @@ func gqlInnerType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		tmpGqlType = parts[len(parts)-1]
 	}
 	tmpGqlType = strings.TrimPrefix(tmpGqlType, "Null")
+	if len(parts) == 1 {
+		// the enums are declared in the package of the models
+		tmpGqlType = gqlTypeName(options, tmpGqlType)
+	}
 
 	return tmpGqlType
 }
+
+// int64Types are the Go types of the 64-bit integer columns.
+var int64Types = map[string]struct{}{
+	"int64":         {},
+	"sql.NullInt64": {},
+	"pgtype.Int8":   {},
+}
+
+// isDecimalColumn reports whether the column keeps the exact numbers,
+// they are strings or the pgtype.Numeric wrappers in Go.
+func isDecimalColumn(col *plugin.Column) bool {
+	if col.Type == nil {
+		return false
+	}
+	switch strings.TrimPrefix(sdk.DataType(col.Type), "pg_catalog.") {
+	case "numeric", "decimal", "money":
+		return true
+	}
+	return false
+}
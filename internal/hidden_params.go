@@ -0,0 +1,147 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// HiddenParam is a parameter of the query that is not exposed in the GraphQL arguments.
+// Its value is put into the resolver context by the @fromContext directive.
+type HiddenParam struct {
+	// Name is the name of the parameter as it would be in the GraphQL arguments
+	Name string
+	// DBName is the name of the parameter in the SQL query
+	DBName string
+	// Key is the key of the value in the request context
+	Key  string
+	Type string
+	// GoField is the name of the field in the params struct of the query
+	// or an empty string if the parameter is the only argument of the query
+	GoField string
+	Column  *plugin.Column
+}
+
+func (p HiddenParam) Directive() string {
+	return fmt.Sprintf("@fromContext(param: %q, key: %q)", p.Name, p.Key)
+}
+
+// parseHiddenParams collects the parameters hidden by the gql-hide comment and by the options.
+// The comment looks like
+//
+//	-- gql-hide: author_id=currentUserId, status
+//
+// where the context key defaults to the name of the argument.
+func parseHiddenParams(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	query *plugin.Query,
+	comments []string,
+) ([]HiddenParam, []string, error) {
+	var hidden []HiddenParam
+	add := func(name, key string, required bool) error {
+		name = strings.TrimSpace(name)
+		key = strings.TrimSpace(key)
+		for _, h := range hidden {
+			if h.DBName == name {
+				return nil
+			}
+		}
+		for _, p := range query.Params {
+			if p.Column.GetName() != name {
+				continue
+			}
+			h := HiddenParam{
+				Name:   paramName(p),
+				DBName: name,
+				Key:    key,
+				Type:   gqlType(req, options, p.Column),
+				Column: p.Column,
+			}
+			hidden = append(hidden, h)
+			return nil
+		}
+		if required {
+			return fmt.Errorf("hidden param %s is not a parameter of the query", name)
+		}
+		return nil
+	}
+
+	for i, comment := range comments {
+		text, ok := strings.CutPrefix(strings.TrimSpace(comment), "gql-hide:")
+		if !ok {
+			continue
+		}
+		for _, param := range strings.Split(text, ",") {
+			name, key, _ := strings.Cut(param, "=")
+			if err := add(name, key, true); err != nil {
+				return nil, nil, err
+			}
+		}
+		comments = append(comments[:i], comments[i+1:]...)
+		break
+	}
+
+	for _, param := range options.HiddenParams {
+		if param.Query != "" && !strings.EqualFold(param.Query, query.Name) {
+			continue
+		}
+		if err := add(param.Param, param.Key, param.Query != ""); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return hidden, comments, nil
+}
+
+// hideParams removes the hidden parameters from the arguments of the query.
+// The hidden parameters are named the same way as the arguments they replace.
+func hideParams(arg QueryValue, hidden []HiddenParam, options *opts.Options) (QueryValue, []HiddenParam) {
+	if len(hidden) == 0 || arg.isEmpty() {
+		return arg, hidden
+	}
+
+	find := func(dbName string) int {
+		for i, h := range hidden {
+			if h.DBName == dbName {
+				return i
+			}
+		}
+		return -1
+	}
+
+	if !arg.IsStruct() {
+		if i := find(arg.DBName); i >= 0 {
+			hidden[i].Name = arg.Name
+			return QueryValue{}, withDefaultKeys(hidden)
+		}
+		return arg, hidden
+	}
+
+	s := *arg.Struct
+	s.Fields = make([]Field, 0, len(arg.Struct.Fields))
+	for _, f := range arg.Struct.Fields {
+		if i := find(f.DBName); i >= 0 {
+			hidden[i].Name = escape(toLowerCase(f.Name))
+			hidden[i].GoField = goFieldName(f, options)
+			continue
+		}
+		s.Fields = append(s.Fields, f)
+	}
+	if len(s.Fields) == 0 {
+		return QueryValue{}, withDefaultKeys(hidden)
+	}
+	arg.Struct = &s
+	return arg, withDefaultKeys(hidden)
+}
+
+func withDefaultKeys(hidden []HiddenParam) []HiddenParam {
+	for i := range hidden {
+		if hidden[i].Key == "" {
+			hidden[i].Key = hidden[i].Name
+		}
+	}
+	return hidden
+}
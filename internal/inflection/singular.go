@@ -45,3 +45,7 @@ func Singular(s SingularParams) string {
 	}
 	return upstream.Singular(s.Name)
 }
+
+func Plural(name string) string {
+	return upstream.Plural(name)
+}
@@ -0,0 +1,47 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+)
+
+// typeName renders the template of the naming option for the name of the generated type.
+// opts.ValidateOpts checks the templates with a sample name only, so they can still fail on the real names.
+func typeName(option string, tmpl string, name string) (string, error) {
+	res, err := opts.TypeName(tmpl, name)
+	if err != nil {
+		return "", fmt.Errorf("naming.%s: %w", option, err)
+	}
+	return res, nil
+}
+
+// gqlTypeName prepends the type_prefix option to the name of the generated GraphQL type.
+func gqlTypeName(options *opts.Options, name string) string {
+	return options.TypePrefix + name
+}
+
+// goModelName returns the name of the Go type of the model path, e.g. Author of authors/storage.Author.
+// Unlike the GraphQL names it has no type prefix.
+func goModelName(modelPath string) string {
+	return modelPath[strings.LastIndex(modelPath, ".")+1:]
+}
+
+// inputName returns the name of the input of the arguments of the query field.
+func inputName(options *opts.Options, resolverName string) (string, error) {
+	name, err := typeName("input", options.Naming.Input, sdk.Title(resolverName))
+	if err != nil {
+		return "", err
+	}
+	return gqlTypeName(options, name), nil
+}
+
+// gqlFieldName returns the name of the GraphQL field of the Go field in the field_case style of the naming option.
+func gqlFieldName(name string, fieldCase string) string {
+	if fieldCase == "snake" {
+		return toSnakeCase(name)
+	}
+	return toLowerCase(name)
+}
@@ -0,0 +1,240 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// NodeType is a type of a table implementing the Relay Node interface.
+// Its id field is the global ID made of the type name and the primary key,
+// and the primary key named id is moved to the rowId field.
+type NodeType struct {
+	Name      string
+	ModelPath string
+	// Key is the single-column primary key of the table
+	Key *plugin.Column
+	// GoField is the name of the key in the model
+	GoField string
+	// Query fetches the row by the key for the node field, it is nil if there is no such query
+	Query *Query
+}
+
+// addNodeFields turns the types of the tables with the primary keys into the nodes.
+func addNodeFields(
+	options *opts.Options,
+	structs []Struct,
+	queries []Query,
+	keys map[string]*plugin.Column,
+) ([]Struct, []NodeType) {
+	var nodes []NodeType
+	for i := range structs {
+		s := &structs[i]
+		key := keys[s.Name]
+		idx := keyField(s, key)
+		if idx < 0 {
+			continue
+		}
+
+		id := StructName("id", options)
+		row := s.Fields[idx]
+		goField := goFieldName(Field{Name: row.Name, DBName: key.Name}, options)
+		fields := make([]Field, 0, len(s.Fields)+1)
+		fields = append(fields, Field{Name: id, Type: "ID!", Directive: "@goField(forceResolver: true)"})
+		if row.Name == id {
+			row.Name = StructName("row_id", options)
+			if !strings.Contains(row.Directive, "@goField") {
+				row.Directive = strings.TrimSpace(fmt.Sprintf("@goField(name: %q) %s", goField, row.Directive))
+			}
+			fields = append(fields, s.Fields[:idx]...)
+			fields = append(fields, row)
+			fields = append(fields, s.Fields[idx+1:]...)
+		} else if hasField(s, id) {
+			fmt.Fprintf(
+				os.Stderr,
+				"WARNING: the %s type has the id field that is not its key %s, so it does not implement Node\n",
+				s.Name, key.Name,
+			)
+			continue
+		} else {
+			fields = append(fields, s.Fields...)
+		}
+		s.Fields = fields
+		s.Implements = "Node"
+
+		node := NodeType{Name: s.Name, ModelPath: s.ModelPath, Key: key, GoField: goField}
+		for _, q := range queries {
+			if isNodeQuery(q, s, key) {
+				node.Query = &q
+				break
+			}
+		}
+		if node.Query == nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"WARNING: the %s type implements Node, but there is no :one query fetching it by %s for the node field\n",
+				s.Name, key.Name,
+			)
+		}
+		nodes = append(nodes, node)
+	}
+	return structs, nodes
+}
+
+// tableKeys returns the single-column primary keys of the tables by the names of their types.
+// sqlc does not pass the constraints to plugins, so the keys are taken from the primary_keys option,
+// then from the :one queries fetching the model of the table by one column, preferring the id one,
+// and the id column is the key by convention if there is no such query.
+func tableKeys(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	structs []Struct,
+	queries []Query,
+) (map[string]*plugin.Column, error) {
+	keys := map[string]*plugin.Column{}
+	for _, name := range options.PrimaryKeys {
+		end, err := findRelationEnd(req, structs, name)
+		if err != nil {
+			return nil, fmt.Errorf("primary key %s: %w", name, err)
+		}
+		keys[structs[end.structIdx].Name] = end.column
+	}
+	for i := range structs {
+		s := &structs[i]
+		if _, ok := keys[s.Name]; ok || s.Table == nil {
+			continue
+		}
+		table := findTable(req, s.Table)
+		if table == nil {
+			continue
+		}
+		if key := queryKey(table, s, queries); key != nil {
+			keys[s.Name] = key
+			continue
+		}
+		for _, c := range table.Columns {
+			if c.Name == "id" {
+				keys[s.Name] = c
+			}
+		}
+	}
+	return keys, nil
+}
+
+// queryKey returns the column the :one queries fetch the model of the table by.
+// It is nil if there is no such query or the queries take different columns and none of them is the id one.
+func queryKey(table *plugin.Table, s *Struct, queries []Query) *plugin.Column {
+	var key *plugin.Column
+	ambiguous := false
+	for _, q := range queries {
+		for _, c := range table.Columns {
+			if !isNodeQuery(q, s, c) {
+				continue
+			}
+			switch {
+			case key == nil || c.Name == "id":
+				key = c
+			case key.Name != c.Name && key.Name != "id":
+				ambiguous = true
+			}
+		}
+	}
+	if key == nil || key.Name == "id" || !ambiguous {
+		return key
+	}
+	fmt.Fprintf(
+		os.Stderr,
+		"WARNING: the :one queries fetch the %s type by different columns, list its key in the primary_keys option\n",
+		s.Name,
+	)
+	return nil
+}
+
+// keyField returns the index of the field of the key column, it is -1 if the type has no such field.
+func keyField(s *Struct, key *plugin.Column) int {
+	if key == nil {
+		return -1
+	}
+	for i, f := range s.Fields {
+		if f.Column != nil && f.Column.Name == key.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasField reports whether the type has the field with the name.
+func hasField(s *Struct, name string) bool {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isNodeQuery reports whether the query takes only the key and returns the model of the table.
+func isNodeQuery(q Query, s *Struct, key *plugin.Column) bool {
+	if q.Cmd != metadata.CmdOne || len(q.Hidden) > 0 || q.Arg.IsStruct() || q.Arg.List || q.Arg.DBName != key.Name {
+		return false
+	}
+	return q.Ret.IsStruct() && !q.Ret.EmitStruct() && q.Ret.Struct.Name == s.Name
+}
+
+// buildNodeResolvers builds the resolver of the node field dispatching the global ID to the queries,
+// and the resolvers of the id fields of the nodes.
+func buildNodeResolvers(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	nodes []NodeType,
+	imports *goImports,
+) []goResolver {
+	imports.add(schemaPackage)
+	imports.add("fmt")
+	node := goResolver{
+		Receiver:   "QueryDelegate",
+		Name:       "Node",
+		FieldName:  "node",
+		Args:       []goArgument{{Name: "id", Type: "string"}},
+		ReturnType: imports.Model(schemaPackage + ".Node"),
+		Body: []string{
+			"typ, key, err := schema.DecodeGlobalID(id)",
+			"if err != nil {",
+			"return nil, err",
+			"}",
+			"switch typ {",
+		},
+	}
+	var ids []goResolver
+	for _, n := range nodes {
+		if n.Query != nil {
+			node.Body = append(
+				node.Body,
+				fmt.Sprintf("case %q:", n.Name),
+				fmt.Sprintf("k, err := schema.GlobalIDKey[%s](key)", imports.Type(goType(req, options, n.Key))),
+				"if err != nil {",
+				"return nil, err",
+				"}",
+				"return d."+options.ResolverQueriesField+"."+n.Query.MethodName+"(ctx, k)",
+			)
+		}
+		ids = append(
+			ids, goResolver{
+				Receiver:   n.Name + "Delegate",
+				Name:       "ID",
+				FieldName:  "id",
+				Args:       []goArgument{{Name: "obj", Type: "*" + imports.Model(n.ModelPath)}},
+				ReturnType: "string",
+				Body: []string{
+					fmt.Sprintf("return schema.EncodeGlobalID(%q, obj.%s)", n.Name, n.GoField),
+				},
+			},
+		)
+	}
+	node.Body = append(node.Body, "}", `return nil, fmt.Errorf("node type %s is not found", typ)`)
+	return append([]goResolver{node}, ids...)
+}
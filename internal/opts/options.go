@@ -4,7 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"text/template"
 
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
 )
@@ -15,6 +20,64 @@ type Directive struct {
 	Directive string `json:"directive" yaml:"directive"`
 }
 
+// HiddenParam removes the parameter of a query from the GraphQL arguments.
+// The value of the parameter is taken from the request context by the key instead.
+type HiddenParam struct {
+	Query string `json:"query" yaml:"query"`
+	Param string `json:"param" yaml:"param"`
+	Key   string `json:"key" yaml:"key"`
+}
+
+// Relation describes a foreign key, e.g. from "posts.author_id" to "authors.id".
+// The referencing type gets the Field, and the referenced type gets the ReverseField with the list of the referencing rows.
+type Relation struct {
+	From         string `json:"from" yaml:"from"`
+	To           string `json:"to" yaml:"to"`
+	Field        string `json:"field,omitempty" yaml:"field"`
+	ReverseField string `json:"reverse_field,omitempty" yaml:"reverse_field"`
+}
+
+// FederationStub declares the key of the type of another subgraph extended by the queries.
+type FederationStub struct {
+	Type string `json:"type" yaml:"type"`
+	// Key is the GraphQL field of the key, id by default
+	Key string `json:"key,omitempty" yaml:"key"`
+	// Column is the column referencing the stub, e.g. "comments.post_sku", the key has its type
+	Column string `json:"column,omitempty" yaml:"column"`
+}
+
+// Naming holds the templates of the names of the generated GraphQL types, e.g. "{{.Name}}Result".
+// The .Name is the field of the query for the input, the name of the query for the row
+// and the name of the item type for the page, the connection and the edge.
+// The @goModel paths keep the names of the types generated by sqlc-gen-go.
+type Naming struct {
+	Input      string `json:"input,omitempty" yaml:"input"`
+	Row        string `json:"row,omitempty" yaml:"row"`
+	Page       string `json:"page,omitempty" yaml:"page"`
+	Connection string `json:"connection,omitempty" yaml:"connection"`
+	Edge       string `json:"edge,omitempty" yaml:"edge"`
+	// FieldCase is the case style of the fields of the types and the inputs, camel or snake
+	FieldCase string `json:"field_case,omitempty" yaml:"field_case"`
+}
+
+var namePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// TypeName renders the naming template for the name.
+func TypeName(tmpl string, name string) (string, error) {
+	t, err := template.New("naming").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, struct{ Name string }{Name: name}); err != nil {
+		return "", err
+	}
+	if !namePattern.MatchString(b.String()) {
+		return "", fmt.Errorf("%q is not a valid GraphQL name", b.String())
+	}
+	return b.String(), nil
+}
+
 type Options struct {
 	Package                     string            `json:"package" yaml:"package"`
 	Out                         string            `json:"out" yaml:"out"`
@@ -31,10 +94,75 @@ type Options struct {
 	OmitUnusedStructs           bool              `json:"omit_unused_structs,omitempty" yaml:"omit_unused_structs"`
 	DefaultSchema               string            `json:"default_schema,omitempty" yaml:"default_schema"`
 	SkipGeneration              bool              `json:"skip_generation,omitempty" yaml:"skip_generation"`
+	SqlPackage                  string            `json:"sql_package" yaml:"sql_package"`
+	EmitPointersForNullTypes    bool              `json:"emit_pointers_for_null_types,omitempty" yaml:"emit_pointers_for_null_types"`
+
+	GenCommonParts      bool          `json:"gen_common_parts,omitempty" yaml:"gen_common_parts"`
+	Exclude             []string      `json:"exclude,omitempty" yaml:"exclude"`
+	Directives          []Directive   `json:"directives,omitempty" yaml:"directives"`
+	EmitOmittableParams bool          `json:"emit_omittable_params,omitempty" yaml:"emit_omittable_params"`
+	HiddenParams        []HiddenParam `json:"hidden_params,omitempty" yaml:"hidden_params"`
+
+	// ExcludeFromOutputs hides the fields from the types only, e.g. *.passwordHash stays in the inputs
+	ExcludeFromOutputs []string `json:"exclude_from_outputs,omitempty" yaml:"exclude_from_outputs"`
+	// ExcludeFromInputs hides the fields from the inputs and the arguments only, e.g. *.createdAt stays in the types
+	ExcludeFromInputs []string `json:"exclude_from_inputs,omitempty" yaml:"exclude_from_inputs"`
+	// ExcludeTypes, ExcludeEnums and ExcludeQueries are the glob patterns of the names of the types, the enums
+	// and the sqlc queries removed from the schema, e.g. SchemaMigration, Audit* or *Internal
+	ExcludeTypes   []string `json:"exclude_types,omitempty" yaml:"exclude_types"`
+	ExcludeEnums   []string `json:"exclude_enums,omitempty" yaml:"exclude_enums"`
+	ExcludeQueries []string `json:"exclude_queries,omitempty" yaml:"exclude_queries"`
+
+	// ResolverPackage is the import path of the package for the generated gqlgen resolver delegates
+	ResolverPackage      string `json:"resolver_package,omitempty" yaml:"resolver_package"`
+	ResolverOut          string `json:"resolver_out,omitempty" yaml:"resolver_out"`
+	ResolverQueriesField string `json:"resolver_queries_field,omitempty" yaml:"resolver_queries_field"`
+
+	Relations []Relation `json:"relations,omitempty" yaml:"relations"`
+	// LoaderPackage is the import path of the package for the generated dataloaders of the relations
+	LoaderPackage string `json:"loader_package,omitempty" yaml:"loader_package"`
+	LoaderOut     string `json:"loader_out,omitempty" yaml:"loader_out"`
+
+	// MarshalPackage is the import path of the package for the generated gqlgen marshalers of the pgtype wrappers
+	MarshalPackage string `json:"marshal_package,omitempty" yaml:"marshal_package"`
+	MarshalOut     string `json:"marshal_out,omitempty" yaml:"marshal_out"`
+
+	// Int64Scalar is the scalar of the 64-bit integer columns, Int64 or BigInt, as Int holds only 32 bits
+	Int64Scalar string `json:"int64_scalar,omitempty" yaml:"int64_scalar"`
+	// EmitDecimalScalar maps the numeric, decimal and money columns to the Decimal scalar instead of String
+	EmitDecimalScalar bool `json:"emit_decimal_scalar,omitempty" yaml:"emit_decimal_scalar"`
 
-	GenCommonParts bool        `json:"gen_common_parts,omitempty" yaml:"gen_common_parts"`
-	Exclude        []string    `json:"exclude,omitempty" yaml:"exclude"`
-	Directives     []Directive `json:"directives,omitempty" yaml:"directives"`
+	// EmitBackwardPagination adds the last and before fields to the inputs of the cursor paginated queries,
+	// they are bound to the Last and Before fields of the params struct that should read them.
+	// The first and after fields become optional, so the backward page is requested without them
+	EmitBackwardPagination bool `json:"emit_backward_pagination,omitempty" yaml:"emit_backward_pagination"`
+
+	// EmitNodeInterface makes the types of the tables with the single-column primary keys implement the Relay Node interface
+	EmitNodeInterface bool `json:"emit_node_interface,omitempty" yaml:"emit_node_interface"`
+	// PrimaryKeys are the single-column primary keys of the tables of the nodes and the entities, e.g. "authors.author_id",
+	// the keys of other tables are the columns their :one queries fetch the rows by
+	PrimaryKeys []string `json:"primary_keys,omitempty" yaml:"primary_keys"`
+	// Federation makes the schema an Apollo Federation subgraph with the types of the tables with the primary keys as entities
+	Federation bool `json:"federation,omitempty" yaml:"federation"`
+	// FederationStubs are the keys of the types of other subgraphs, the stubs are keyed by id by default
+	FederationStubs []FederationStub `json:"federation_stubs,omitempty" yaml:"federation_stubs"`
+
+	// SchemaLock is the path of the schema lockfile the generated schema is compared with, relative to the working directory of sqlc,
+	// it is read from the host filesystem, so it requires the process plugin
+	SchemaLock string `json:"schema_lock,omitempty" yaml:"schema_lock"`
+	// SchemaLockOut is the path the new lockfile is generated to, relative to the "out" directory (the name of the lockfile by default)
+	SchemaLockOut string `json:"schema_lock_out,omitempty" yaml:"schema_lock_out"`
+	// AcceptBreakingChanges are the coordinates of the breaking changes allowed by the schema lock, e.g. Author.bio or Status.BANNED
+	AcceptBreakingChanges []string `json:"accept_breaking_changes,omitempty" yaml:"accept_breaking_changes"`
+
+	// TypePrefix is prepended to the names of all the generated types, e.g. Post for PostStatus,
+	// to keep apart the types of the modules sharing one schema
+	TypePrefix string `json:"type_prefix,omitempty" yaml:"type_prefix"`
+	// Naming changes the names of the generated input, row, page, connection and edge types and the case of the fields
+	Naming Naming `json:"naming,omitempty" yaml:"naming"`
+	// Deprecated maps the fields, the arguments, the query fields and the enum values to the reasons of their deprecation,
+	// the keys look like the keys of Exclude, e.g. Author.bio, AuthorsInput.name, Query.authors or Status.active
+	Deprecated map[string]string `json:"deprecated,omitempty" yaml:"deprecated"`
 }
 
 type GlobalOptions struct {
@@ -86,6 +214,46 @@ func parseOpts(req *plugin.GenerateRequest) (*Options, error) {
 		}
 	}
 
+	if options.ResolverPackage != "" {
+		if options.ResolverOut == "" {
+			options.ResolverOut = path.Base(options.ResolverPackage)
+		}
+		if options.ResolverQueriesField == "" {
+			options.ResolverQueriesField = "Queries"
+		}
+	}
+
+	if options.LoaderPackage != "" && options.LoaderOut == "" {
+		options.LoaderOut = path.Base(options.LoaderPackage)
+	}
+
+	if options.MarshalPackage != "" && options.MarshalOut == "" {
+		options.MarshalOut = path.Base(options.MarshalPackage)
+	}
+
+	if options.SchemaLock != "" && options.SchemaLockOut == "" {
+		options.SchemaLockOut = path.Base(filepath.ToSlash(options.SchemaLock))
+	}
+
+	if options.Naming.Input == "" {
+		options.Naming.Input = "{{.Name}}Input"
+	}
+	if options.Naming.Row == "" {
+		options.Naming.Row = "{{.Name}}Row"
+	}
+	if options.Naming.Page == "" {
+		options.Naming.Page = "{{.Name}}Page"
+	}
+	if options.Naming.Connection == "" {
+		options.Naming.Connection = "{{.Name}}Connection"
+	}
+	if options.Naming.Edge == "" {
+		options.Naming.Edge = "{{.Name}}Edge"
+	}
+	if options.Naming.FieldCase == "" {
+		options.Naming.FieldCase = "camel"
+	}
+
 	if options.QueryParameterLimit == nil {
 		options.QueryParameterLimit = new(int32)
 		*options.QueryParameterLimit = 1
@@ -114,6 +282,53 @@ func ValidateOpts(opts *Options) error {
 	if *opts.QueryParameterLimit < 0 {
 		return fmt.Errorf("invalid options: query parameter limit must not be negative")
 	}
+	if opts.SqlPackage != "" {
+		if err := validatePackage(opts.SqlPackage); err != nil {
+			return fmt.Errorf("invalid options: %s", err)
+		}
+	}
+	// the wasm plugins have no access to the host filesystem to read the lockfile
+	if opts.SchemaLock != "" && runtime.GOOS == "wasip1" {
+		return fmt.Errorf("invalid options: schema_lock requires the process plugin, the wasm plugin can not read the lockfile")
+	}
+	switch opts.Int64Scalar {
+	case "", "Int64", "BigInt":
+	default:
+		return fmt.Errorf("invalid options: int64_scalar must be Int64 or BigInt, got %q", opts.Int64Scalar)
+	}
+	for _, patterns := range [][]string{opts.ExcludeTypes, opts.ExcludeEnums, opts.ExcludeQueries} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid options: invalid exclude pattern %s: %s", pattern, err)
+			}
+		}
+	}
+	if opts.TypePrefix != "" && !namePattern.MatchString(opts.TypePrefix) {
+		return fmt.Errorf("invalid options: type_prefix %q is not a valid GraphQL name", opts.TypePrefix)
+	}
+	for _, naming := range []struct{ option, tmpl string }{
+		{"input", opts.Naming.Input},
+		{"row", opts.Naming.Row},
+		{"page", opts.Naming.Page},
+		{"connection", opts.Naming.Connection},
+		{"edge", opts.Naming.Edge},
+	} {
+		if _, err := TypeName(naming.tmpl, "Author"); err != nil {
+			return fmt.Errorf("invalid options: naming.%s: %s", naming.option, err)
+		}
+	}
+	switch opts.Naming.FieldCase {
+	case "camel", "snake":
+	default:
+		return fmt.Errorf("invalid options: naming.field_case must be camel or snake, got %q", opts.Naming.FieldCase)
+	}
+	// the params structs of sqlc-gen-go have no Omittable fields, so the inputs are declared next to the delegates
+	if opts.EmitOmittableParams && opts.ResolverPackage == "" {
+		return fmt.Errorf("invalid options: emit_omittable_params requires resolver_package")
+	}
+	if len(opts.AcceptBreakingChanges) > 0 && opts.SchemaLock == "" {
+		return fmt.Errorf("invalid options: accept_breaking_changes requires schema_lock")
+	}
 
 	return nil
 }
@@ -0,0 +1,172 @@
+package golang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// CursorColumn is a column of the ordering of the cursor paginated query.
+type CursorColumn struct {
+	// Name is the name of the column in the result of the query
+	Name string
+	Desc bool
+}
+
+// Direction returns the SQL sort direction of the column.
+func (c CursorColumn) Direction() string {
+	if c.Desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// parsePagination parses the pagination comment of the query. The comment looks like
+//
+//	-- paginated: offset
+//	-- paginated: cursor:created_at desc,id desc
+//
+// where the cursor columns are sorted ascending by default.
+// The descending order can be set by the "-" prefix as well: cursor:-created_at,-id
+func parsePagination(comments []string) (paginated bool, cursor bool, order []CursorColumn, rest []string, err error) {
+	for i, comment := range comments {
+		text, ok := strings.CutPrefix(strings.TrimSpace(comment), "paginated")
+		if !ok {
+			continue
+		}
+		rest = append(comments[:i], comments[i+1:]...)
+		text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), ":"))
+		spec, ok := strings.CutPrefix(text, "cursor")
+		if !ok {
+			return true, false, nil, rest, nil
+		}
+		order, err = parseCursorOrder(strings.TrimPrefix(strings.TrimSpace(spec), ":"))
+		return true, true, order, rest, err
+	}
+	return false, false, nil, comments, nil
+}
+
+func parseCursorOrder(spec string) ([]CursorColumn, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	var order []CursorColumn
+	for _, item := range strings.Split(spec, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, fmt.Errorf("invalid cursor column %q, it should be in the format of 'column [asc|desc]'", item)
+		}
+		c := CursorColumn{Name: parts[0]}
+		if name, ok := strings.CutPrefix(c.Name, "-"); ok {
+			c.Name = name
+			c.Desc = true
+		}
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				c.Desc = true
+			default:
+				return nil, fmt.Errorf("invalid sort direction %q of the cursor column %s", parts[1], c.Name)
+			}
+		}
+		for _, o := range order {
+			if o.Name == c.Name {
+				return nil, fmt.Errorf("cursor column %s is repeated", c.Name)
+			}
+		}
+		order = append(order, c)
+	}
+	return order, nil
+}
+
+// checkCursorColumns checks that the cursor columns are in the result of the query.
+func checkCursorColumns(order []CursorColumn, columns []*plugin.Column) error {
+	for _, c := range order {
+		if !hasResultColumn(columns, c.Name) {
+			return fmt.Errorf("cursor column %s is not found in the result of the query", c.Name)
+		}
+	}
+	return nil
+}
+
+func hasResultColumn(columns []*plugin.Column, name string) bool {
+	for i, column := range columns {
+		if column.EmbedTable == nil && columnName(column, i) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// orderByPlaceholder is schema.OrderByPlaceholder replaced with the sort keys chosen by the client.
+const orderByPlaceholder = "/* order by */"
+
+// Sort is the ordering of the paginated query chosen by the client
+// among the columns listed in the gql-sort comment.
+type Sort struct {
+	// Name is the name of the sorted type
+	Name    string
+	Columns []string
+	// Emit is false if the same input is declared by another query
+	Emit bool
+}
+
+func (s Sort) InputName() string {
+	return s.Name + "OrderBy"
+}
+
+func (s Sort) FieldEnumName() string {
+	return s.Name + "OrderField"
+}
+
+// parseSort parses the columns of the gql-sort comment of the query. The comment looks like
+//
+//	-- gql-sort: name, created_at
+func parseSort(comments []string, columns []*plugin.Column) ([]string, []string, error) {
+	for i, comment := range comments {
+		text, ok := strings.CutPrefix(strings.TrimSpace(comment), "gql-sort:")
+		if !ok {
+			continue
+		}
+		comments = append(comments[:i], comments[i+1:]...)
+
+		var sortColumns []string
+		for _, name := range strings.Split(text, ",") {
+			name = strings.TrimSpace(name)
+			if !hasResultColumn(columns, name) {
+				return nil, nil, fmt.Errorf("sort column %s is not found in the result of the query", name)
+			}
+			if !slices.Contains(sortColumns, name) {
+				sortColumns = append(sortColumns, name)
+			}
+		}
+		return sortColumns, comments, nil
+	}
+	return nil, comments, nil
+}
+
+// emitSortInputs declares the sort inputs once for each sorted type.
+func emitSortInputs(queries []Query) error {
+	declared := map[string]Query{}
+	for i, q := range queries {
+		if q.Sort == nil {
+			continue
+		}
+		d, ok := declared[q.Sort.Name]
+		if !ok {
+			q.Sort.Emit = true
+			declared[q.Sort.Name] = queries[i]
+			continue
+		}
+		if !slices.Equal(d.Sort.Columns, q.Sort.Columns) {
+			return fmt.Errorf(
+				"%s is sorted by different columns in the queries %s and %s",
+				q.Sort.Name, d.MethodName, q.MethodName,
+			)
+		}
+	}
+	return nil
+}
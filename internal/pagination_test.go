@@ -0,0 +1,76 @@
+package golang
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePagination_CursorOrder(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    []CursorColumn
+	}{
+		{
+			comment: "paginated: cursor:name,id",
+			want:    []CursorColumn{{Name: "name"}, {Name: "id"}},
+		},
+		{
+			comment: "paginated:cursor:created_at desc,id desc",
+			want:    []CursorColumn{{Name: "created_at", Desc: true}, {Name: "id", Desc: true}},
+		},
+		{
+			comment: "paginated: cursor: created_at DESC, name asc, id",
+			want:    []CursorColumn{{Name: "created_at", Desc: true}, {Name: "name"}, {Name: "id"}},
+		},
+		{
+			comment: "paginated: cursor:-name,id",
+			want:    []CursorColumn{{Name: "name", Desc: true}, {Name: "id"}},
+		},
+		{
+			comment: "paginated: cursor",
+			want:    nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.comment, func(t *testing.T) {
+			paginated, cursor, order, rest, err := parsePagination([]string{tc.comment})
+			if err != nil {
+				t.Fatalf("parsePagination failed: %v", err)
+			}
+			if !paginated || !cursor || len(rest) != 0 {
+				t.Errorf("parsePagination failed. the cursor pagination comment is not recognized")
+			}
+			if !reflect.DeepEqual(order, tc.want) {
+				t.Errorf("parsePagination failed. want %v, got %v", tc.want, order)
+			}
+		})
+	}
+}
+
+func TestParsePagination_InvalidCursorOrder(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    string
+	}{
+		{
+			comment: "paginated: cursor:name up,id",
+			want:    `invalid sort direction "up" of the cursor column name`,
+		},
+		{
+			comment: "paginated: cursor:name,,id",
+			want:    `invalid cursor column "", it should be in the format of 'column [asc|desc]'`,
+		},
+		{
+			comment: "paginated: cursor:id,id desc",
+			want:    "cursor column id is repeated",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.comment, func(t *testing.T) {
+			_, _, _, _, err := parsePagination([]string{tc.comment})
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("parsePagination failed. want error %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -37,57 +37,110 @@ func parseIdentifierString(name string) (*plugin.Identifier, error) {
 func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
 	columnType := sdk.DataType(col.Type)
 	notNull := col.NotNull || col.IsArray
+	driver := parseDriver(options.SqlPackage)
+	emitPointersForNull := driver.IsPGX() && options.EmitPointersForNullTypes
 
 	switch columnType {
 	case "serial", "serial4", "pg_catalog.serial4":
 		if notNull {
 			return "int32"
 		}
+		if emitPointersForNull {
+			return "*int32"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Int4"
+		}
 		return "sql.NullInt32"
 
 	case "bigserial", "serial8", "pg_catalog.serial8":
 		if notNull {
 			return "int64"
 		}
+		if emitPointersForNull {
+			return "*int64"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Int8"
+		}
 		return "sql.NullInt64"
 
 	case "smallserial", "serial2", "pg_catalog.serial2":
 		if notNull {
 			return "int16"
 		}
+		if emitPointersForNull {
+			return "*int16"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Int2"
+		}
 		return "sql.NullInt16"
 
 	case "integer", "int", "int4", "pg_catalog.int4":
 		if notNull {
 			return "int32"
 		}
+		if emitPointersForNull {
+			return "*int32"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Int4"
+		}
 		return "sql.NullInt32"
 
 	case "bigint", "int8", "pg_catalog.int8":
 		if notNull {
 			return "int64"
 		}
+		if emitPointersForNull {
+			return "*int64"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Int8"
+		}
 		return "sql.NullInt64"
 
 	case "smallint", "int2", "pg_catalog.int2":
 		if notNull {
 			return "int16"
 		}
+		if emitPointersForNull {
+			return "*int16"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Int2"
+		}
 		return "sql.NullInt16"
 
 	case "float", "double precision", "float8", "pg_catalog.float8":
 		if notNull {
 			return "float64"
 		}
+		if emitPointersForNull {
+			return "*float64"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Float8"
+		}
 		return "sql.NullFloat64"
 
 	case "real", "float4", "pg_catalog.float4":
 		if notNull {
 			return "float32"
 		}
+		if emitPointersForNull {
+			return "*float32"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Float4"
+		}
 		return "sql.NullFloat64" // TODO: Change to sql.NullFloat32 after updating the go.mod file
 
 	case "numeric", "pg_catalog.numeric", "money":
+		if driver.IsPGX() {
+			return "pgtype.Numeric"
+		}
 		// Since the Go standard library does not have a decimal type, lib/pq
 		// returns numerics as strings.
 		//
@@ -95,80 +148,156 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		if notNull {
 			return "string"
 		}
-
 		return "sql.NullString"
 
 	case "boolean", "bool", "pg_catalog.bool":
 		if notNull {
 			return "bool"
 		}
+		if emitPointersForNull {
+			return "*bool"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Bool"
+		}
 		return "sql.NullBool"
 
 	case "json":
-		return "pgtype.JSON"
+		switch driver {
+		case opts.SQLDriverPGXV5:
+			return "[]byte"
+		case opts.SQLDriverPGXV4:
+			return "pgtype.JSON"
+		default:
+			if notNull {
+				return "json.RawMessage"
+			}
+			return "pqtype.NullRawMessage"
+		}
 
 	case "jsonb":
-		return "pgtype.JSONB"
+		switch driver {
+		case opts.SQLDriverPGXV5:
+			return "[]byte"
+		case opts.SQLDriverPGXV4:
+			return "pgtype.JSONB"
+		default:
+			if notNull {
+				return "json.RawMessage"
+			}
+			return "pqtype.NullRawMessage"
+		}
 
 	case "bytea", "blob", "pg_catalog.bytea":
 		return "[]byte"
 
 	case "date":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Date"
+		}
 		if notNull {
 			return "time.Time"
 		}
-
+		if emitPointersForNull {
+			return "*time.Time"
+		}
 		return "sql.NullTime"
 
 	case "pg_catalog.time":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Time"
+		}
 		if notNull {
 			return "time.Time"
 		}
+		if emitPointersForNull {
+			return "*time.Time"
+		}
 		return "sql.NullTime"
 
 	case "pg_catalog.timetz":
 		if notNull {
 			return "time.Time"
 		}
+		if emitPointersForNull {
+			return "*time.Time"
+		}
 		return "sql.NullTime"
 
 	case "pg_catalog.timestamp":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Timestamp"
+		}
 		if notNull {
 			return "time.Time"
 		}
+		if emitPointersForNull {
+			return "*time.Time"
+		}
 		return "sql.NullTime"
 
 	case "pg_catalog.timestamptz", "timestamptz":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Timestamptz"
+		}
 		if notNull {
 			return "time.Time"
 		}
+		if emitPointersForNull {
+			return "*time.Time"
+		}
 		return "sql.NullTime"
 
 	case "text", "pg_catalog.varchar", "pg_catalog.bpchar", "string", "citext", "name":
 		if notNull {
 			return "string"
 		}
+		if emitPointersForNull {
+			return "*string"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Text"
+		}
 		return "sql.NullString"
 
 	case "uuid":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.UUID"
+		}
 		if notNull {
 			return "uuid.UUID"
 		}
+		if emitPointersForNull {
+			return "*uuid.UUID"
+		}
 		return "uuid.NullUUID"
 
 	case "inet":
-		if notNull {
-			return "netip.Addr"
+		switch driver {
+		case opts.SQLDriverPGXV4:
+			return "pgtype.Inet"
+		default:
+			if notNull {
+				return "netip.Addr"
+			}
+			return "*netip.Addr"
 		}
-		return "*netip.Addr"
 
 	case "cidr":
-		if notNull {
-			return "netip.Prefix"
+		switch driver {
+		case opts.SQLDriverPGXV4:
+			return "pgtype.CIDR"
+		default:
+			if notNull {
+				return "netip.Prefix"
+			}
+			return "*netip.Prefix"
 		}
-		return "*netip.Prefix"
 
 	case "macaddr", "macaddr8":
+		if driver == opts.SQLDriverPGXV4 {
+			return "pgtype.Macaddr"
+		}
 		return "net.HardwareAddr"
 
 	case "ltree", "lquery", "ltxtquery":
@@ -180,49 +309,120 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		if notNull {
 			return "string"
 		}
-
+		if emitPointersForNull {
+			return "*string"
+		}
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Text"
+		}
 		return "sql.NullString"
 
 	case "interval", "pg_catalog.interval":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Interval"
+		}
 		if notNull {
 			return "int64"
 		}
+		if emitPointersForNull {
+			return "*int64"
+		}
 		return "sql.NullInt64"
 
 	case "daterange":
-		return "pgtype.Range[pgtype.Date]"
+		switch driver {
+		case opts.SQLDriverPGXV4:
+			return "pgtype.Daterange"
+		case opts.SQLDriverPGXV5:
+			return "pgtype.Range[pgtype.Date]"
+		default:
+			return "interface{}"
+		}
 
 	case "datemultirange":
-		return "pgtype.Multirange[pgtype.Range[pgtype.Date]]"
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Multirange[pgtype.Range[pgtype.Date]]"
+		}
+		return "interface{}"
 
 	case "tsrange":
-		return "interface{}"
+		switch driver {
+		case opts.SQLDriverPGXV4:
+			return "pgtype.Tsrange"
+		case opts.SQLDriverPGXV5:
+			return "pgtype.Range[pgtype.Timestamp]"
+		default:
+			return "interface{}"
+		}
 
 	case "tsmultirange":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Multirange[pgtype.Range[pgtype.Timestamp]]"
+		}
 		return "interface{}"
 
 	case "tstzrange":
-		return "interface{}"
+		switch driver {
+		case opts.SQLDriverPGXV4:
+			return "pgtype.Tstzrange"
+		case opts.SQLDriverPGXV5:
+			return "pgtype.Range[pgtype.Timestamptz]"
+		default:
+			return "interface{}"
+		}
 
 	case "tstzmultirange":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Multirange[pgtype.Range[pgtype.Timestamptz]]"
+		}
 		return "interface{}"
 
 	case "numrange":
-		return "interface{}"
+		switch driver {
+		case opts.SQLDriverPGXV4:
+			return "pgtype.Numrange"
+		case opts.SQLDriverPGXV5:
+			return "pgtype.Range[pgtype.Numeric]"
+		default:
+			return "interface{}"
+		}
 
 	case "nummultirange":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Multirange[pgtype.Range[pgtype.Numeric]]"
+		}
 		return "interface{}"
 
 	case "int4range":
-		return "interface{}"
+		switch driver {
+		case opts.SQLDriverPGXV4:
+			return "pgtype.Int4range"
+		case opts.SQLDriverPGXV5:
+			return "pgtype.Range[pgtype.Int4]"
+		default:
+			return "interface{}"
+		}
 
 	case "int4multirange":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Multirange[pgtype.Range[pgtype.Int4]]"
+		}
 		return "interface{}"
 
 	case "int8range":
-		return "interface{}"
+		switch driver {
+		case opts.SQLDriverPGXV4:
+			return "pgtype.Int8range"
+		case opts.SQLDriverPGXV5:
+			return "pgtype.Range[pgtype.Int8]"
+		default:
+			return "interface{}"
+		}
 
 	case "int8multirange":
+		if driver == opts.SQLDriverPGXV5 {
+			return "pgtype.Multirange[pgtype.Range[pgtype.Int8]]"
+		}
 		return "interface{}"
 
 	case "hstore":
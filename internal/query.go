@@ -18,6 +18,14 @@ type QueryValue struct {
 	Typ       string
 	ModelPath string
 	SQLDriver opts.SQLDriver
+	// Declared are the arguments written in the gql comment of the query
+	Declared []Argument
+	// List is true if the value is passed as a list, e.g. to the batch queries
+	List bool
+	// Deprecated is the reason of the deprecation of the argument made from the value that is not a struct
+	Deprecated string
+	// FieldCase is the case style of the arguments made from the fields of the struct
+	FieldCase string
 
 	// Column is kept so late in the generation process around to differentiate
 	// between mysql slices and pg arrays
@@ -32,20 +40,47 @@ func (v QueryValue) IsStruct() bool {
 	return v.Struct != nil
 }
 
+// hasOmittableFields reports whether the input has the fields bound to graphql.Omittable.
+func (v QueryValue) hasOmittableFields() bool {
+	if v.Struct == nil {
+		return false
+	}
+	for _, f := range v.Struct.Fields {
+		if f.Omittable {
+			return true
+		}
+	}
+	return false
+}
+
 func (v QueryValue) isEmpty() bool {
 	return v.Typ == "" && v.Name == "" && v.Struct == nil
 }
 
 type Argument struct {
-	Name string
-	Type string
+	Name      string
+	Type      string
+	Default   string
+	Directive string
 }
 
+// Pair renders the arguments of a GraphQL field. Arguments made from nullable
+// SQL parameters (nullable columns or sqlc.narg) stay optional.
 func (v QueryValue) Pair() string {
 	var out []string
-	for _, arg := range v.Pairs() {
-		t := strings.TrimRight(arg.Type, "!")
-		out = append(out, arg.Name+": "+t+"!")
+	args := v.Pairs()
+	if v.Declared != nil {
+		args = v.Declared
+	}
+	for _, arg := range args {
+		pair := arg.Name + ": " + arg.Type
+		if arg.Default != "" {
+			pair += " = " + arg.Default
+		}
+		if arg.Directive != "" {
+			pair += " " + arg.Directive
+		}
+		out = append(out, pair)
 	}
 	return strings.Join(out, ",")
 }
@@ -61,8 +96,9 @@ func (v QueryValue) Pairs() []Argument {
 		for _, f := range v.Struct.Fields {
 			out = append(
 				out, Argument{
-					Name: escape(toLowerCase(f.Name)),
-					Type: f.Type,
+					Name:      escape(gqlFieldName(f.Name, v.FieldCase)),
+					Type:      f.Type,
+					Directive: deprecatedDirective(f.Deprecated),
 				},
 			)
 		}
@@ -70,17 +106,31 @@ func (v QueryValue) Pairs() []Argument {
 	}
 	return []Argument{
 		{
-			Name: escape(v.Name),
-			Type: v.DefineType(),
+			Name:      escape(v.Name),
+			Type:      v.argType(),
+			Directive: deprecatedDirective(v.Deprecated),
 		},
 	}
 }
 
+// argType returns the type of the value used as a field argument. Input
+// objects are always required, scalars keep the nullability of the parameter.
+func (v *QueryValue) argType() string {
+	t := v.DefineType()
+	if (v.IsStruct() || v.List) && !strings.HasSuffix(t, "!") {
+		t += "!"
+	}
+	if v.List {
+		t = "[" + t + "]!"
+	}
+	return t
+}
+
 func (v QueryValue) SlicePair() string {
 	if v.isEmpty() {
 		return ""
 	}
-	return v.Name + ": [" + v.DefineType() + "]!"
+	return v.Name + ": [" + strings.TrimSuffix(v.DefineType(), "!") + "!]!"
 }
 
 func (v QueryValue) Type() string {
@@ -260,9 +310,49 @@ type Query struct {
 	Directive    string
 	Ret          QueryValue
 	Arg          QueryValue
+	// DeclaredType is the return type written in the gql comment of the query
+	DeclaredType string
+	// Hidden are the parameters of the query taken from the request context
+	Hidden []HiddenParam
 
 	Paginated        bool
 	CursorPagination bool
+	// CursorOrder is the ordering of the rows set in the cursor pagination comment
+	CursorOrder []CursorColumn
+	// Sort is the ordering chosen by the client in the orderBy field of the input
+	Sort *Sort
+	// Filter is the condition chosen by the client in the filter argument
+	Filter *Filter
+	// Notify is the channel of the notifications with the keys the subscription re-runs the query for
+	Notify string
+	// Deprecated is the reason of the deprecation of the query field
+	Deprecated string
+	// PageName is the name of the page or the connection returned by the paginated query
+	PageName string
+	// EdgeName is the name of the edge of the connection returned by the cursor paginated query
+	EdgeName string
+}
+
+// wrapsParams reports whether the input is bound to the struct declared next to the delegates,
+// it embeds the params struct of the query and adds the sort keys and the omittable fields missing in it.
+func (q Query) wrapsParams() bool {
+	return q.Sort != nil || q.Arg.hasOmittableFields()
+}
+
+// FieldArgs returns the arguments of the field of the query:
+// the arguments of the query followed by the filter chosen by the client.
+func (q Query) FieldArgs() string {
+	if q.Notify != "" {
+		return ""
+	}
+	var args []string
+	if pair := q.Arg.Pair(); pair != "" {
+		args = append(args, pair)
+	}
+	if q.Filter != nil {
+		args = append(args, gqlFieldName("Filter", q.Arg.FieldCase)+": "+q.Filter.InputName())
+	}
+	return strings.Join(args, ",")
 }
 
 func (q Query) hasRetType() bool {
@@ -279,10 +369,36 @@ func (q Query) ReturnedType() string {
 		return ""
 	}
 	if q.Paginated {
-		if q.CursorPagination {
-			return fmt.Sprintf("%sConnection!", q.Ret.Struct.Name)
-		}
-		return fmt.Sprintf("%sPage!", q.Ret.Struct.Name)
+		return q.PageName + "!"
 	}
 	return fmt.Sprintf("[%s]!", q.Ret.DefineType())
 }
+
+// FieldType returns the GraphQL type of the field generated for the query
+// or an empty string if the command of the query is not supported.
+func (q Query) FieldType() string {
+	if q.DeclaredType != "" {
+		return q.DeclaredType
+	}
+	switch q.Cmd {
+	case metadata.CmdOne:
+		return q.Ret.DefineType()
+	case metadata.CmdMany:
+		return q.ReturnedType()
+	case metadata.CmdExec:
+		return "Boolean!"
+	case metadata.CmdExecRows, metadata.CmdCopyFrom:
+		return "Int!"
+	case metadata.CmdBatchExec:
+		return "Boolean!"
+	case metadata.CmdExecLastId:
+		return "ID!"
+	case metadata.CmdExecResult:
+		return "ExecResult!"
+	case metadata.CmdBatchOne:
+		return "[" + strings.TrimSuffix(q.Ret.DefineType(), "!") + "!]!"
+	case metadata.CmdBatchMany:
+		return "[[" + strings.TrimSuffix(q.Ret.DefineType(), "!") + "!]!]!"
+	}
+	return ""
+}
@@ -0,0 +1,296 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// RangeType is a GraphQL object with the bounds of the range columns of pgx/v5.
+// The object and its input are bound to the adapter of the schema package,
+// so the fields of these types are resolved by the converters of the adapter.
+type RangeType struct {
+	// GoType is the type of the range in the code of sqlc-gen-go
+	GoType string
+	Name   string
+	// Bound is the scalar of the lower and the upper bounds
+	Bound string
+	// Int64 is true if the bounds are the 64-bit integers mapped to the int64_scalar option
+	Int64 bool
+}
+
+func (r RangeType) Model() string {
+	return schemaPackage + "." + r.Name
+}
+
+func (r RangeType) InputName() string {
+	return r.Name + "Input"
+}
+
+var rangeTypes = []RangeType{
+	{GoType: "pgtype.Range[pgtype.Date]", Name: "DateRange", Bound: "Time"},
+	{GoType: "pgtype.Range[pgtype.Timestamp]", Name: "TimestampRange", Bound: "Time"},
+	{GoType: "pgtype.Range[pgtype.Timestamptz]", Name: "TimestampRange", Bound: "Time"},
+	{GoType: "pgtype.Range[pgtype.Numeric]", Name: "NumericRange", Bound: "String"},
+	{GoType: "pgtype.Range[pgtype.Int4]", Name: "IntRange", Bound: "Int"},
+	{GoType: "pgtype.Range[pgtype.Int8]", Name: "BigIntRange", Bound: "Int", Int64: true},
+}
+
+// findRangeType returns the range type of the range or the multirange Go type,
+// the multiranges are the lists of the ranges.
+func findRangeType(goType string) (r RangeType, multi bool, ok bool) {
+	inner, multi := strings.CutPrefix(goType, "pgtype.Multirange[")
+	if multi {
+		inner = strings.TrimSuffix(inner, "]")
+	}
+	for _, r := range rangeTypes {
+		if r.GoType == inner {
+			return r, multi, true
+		}
+	}
+	return RangeType{}, false, false
+}
+
+// gqlRangeType returns the GraphQL type of the range or the multirange Go type.
+func gqlRangeType(goType string) (string, bool) {
+	r, multi, ok := findRangeType(goType)
+	if !ok {
+		return "", false
+	}
+	if multi {
+		return "[" + r.Name + "!]", true
+	}
+	return r.Name, true
+}
+
+// rangeAdapter converts the range or the multirange value of pgx by the adapter of the schema package,
+// e.g. by schema.NewInt4Range and (*schema.IntRange).Int4 for pgtype.Range[pgtype.Int4].
+type rangeAdapter struct {
+	Range RangeType
+	Multi bool
+	// Bounds is the pgx type of the bounds naming the converters, e.g. Int4
+	Bounds string
+}
+
+func newRangeAdapter(goType string) (rangeAdapter, bool) {
+	r, multi, ok := findRangeType(goType)
+	if !ok {
+		return rangeAdapter{}, false
+	}
+	bounds := strings.TrimSuffix(strings.TrimPrefix(r.GoType, "pgtype.Range[pgtype."), "]")
+	return rangeAdapter{Range: r, Multi: multi, Bounds: bounds}, true
+}
+
+// OutputType returns the Go type of the adapter resolving the range field.
+func (a rangeAdapter) OutputType(imports *goImports) string {
+	if a.Multi {
+		return "[]*" + imports.Model(a.Range.Model())
+	}
+	return "*" + imports.Model(a.Range.Model())
+}
+
+// InputType returns the Go type of the adapter of the range argument or input field,
+// gqlgen passes the required input objects by value.
+func (a rangeAdapter) InputType(imports *goImports, gqlType string) string {
+	if !a.Multi && strings.HasSuffix(gqlType, "!") {
+		return imports.Model(a.Range.Model())
+	}
+	return a.OutputType(imports)
+}
+
+// From returns the expression converting the value of pgx to the adapter.
+func (a rangeAdapter) From(imports *goImports, value string) string {
+	constructor := imports.Model(schemaPackage + ".New" + a.Bounds + "Range")
+	if a.Multi {
+		return imports.Model(schemaPackage+".Ranges") + "(" + value + ", " + constructor + ")"
+	}
+	return constructor + "(" + value + ")"
+}
+
+// To returns the expression converting the adapter back to the value of pgx.
+func (a rangeAdapter) To(imports *goImports, value string) string {
+	if a.Multi {
+		return fmt.Sprintf(
+			"%s(%s, (*%s).%s)", imports.Model(schemaPackage+".Multirange"), value, imports.Model(a.Range.Model()), a.Bounds,
+		)
+	}
+	return value + "." + a.Bounds + "()"
+}
+
+// isRangeType reports whether the GraphQL type is a range object or its input.
+func isRangeType(typ string) bool {
+	name := strings.TrimSuffix(baseType(typ), "Input")
+	for _, r := range rangeTypes {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeInputType replaces the range object with its input in the type of an argument.
+func rangeInputType(typ string) string {
+	name := baseType(typ)
+	if !isRangeType(name) || strings.HasSuffix(name, "Input") {
+		return typ
+	}
+	return strings.Replace(typ, name, name+"Input", 1)
+}
+
+// addRangeResolvers marks the range fields to be resolved by the adapters,
+// as their types differ from the types of pgx.
+func addRangeResolvers(fields []Field) []Field {
+	res := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if isRangeType(f.Type) && !strings.Contains(f.Directive, "@goField") {
+			f.Directive += " @goField(forceResolver: true)"
+		}
+		f.Directive = strings.TrimSpace(f.Directive)
+		res = append(res, f)
+	}
+	return res
+}
+
+// addRangeInputFields replaces the range objects of the input fields with their inputs.
+func addRangeInputFields(fields []Field) []Field {
+	res := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		f.Type = rangeInputType(f.Type)
+		res = append(res, f)
+	}
+	return addRangeResolvers(res)
+}
+
+// usedRangeTypes returns the range types referenced by the generated types and fields.
+// The 64-bit integer bounds get the scalar of the int64_scalar option.
+func usedRangeTypes(options *opts.Options, structs []Struct, queries []Query) []RangeType {
+	used := usedTypes(structs, queries)
+	var ranges []RangeType
+	seen := map[string]struct{}{}
+	for _, r := range rangeTypes {
+		if _, ok := seen[r.Name]; ok {
+			continue
+		}
+		_, object := used[r.Name]
+		_, input := used[r.InputName()]
+		if object || input {
+			seen[r.Name] = struct{}{}
+			if r.Int64 && options.Int64Scalar != "" {
+				r.Bound = options.Int64Scalar
+			}
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// hasRangeValue reports whether the batch query or the subscription takes or returns a range outside of a model,
+// the delegates do not convert the lists of the batches and the notified rows, so such resolvers are written by hand.
+func hasRangeValue(q Query) bool {
+	switch q.Cmd {
+	case metadata.CmdBatchOne, metadata.CmdBatchMany, metadata.CmdBatchExec, metadata.CmdCopyFrom:
+	default:
+		if q.Notify == "" {
+			return false
+		}
+	}
+	if !q.Arg.EmitStruct() {
+		for _, arg := range q.Arg.Pairs() {
+			if isRangeType(arg.Type) {
+				return true
+			}
+		}
+	}
+	return !q.Ret.IsStruct() && !q.Ret.isEmpty() && isRangeType(q.Ret.Type())
+}
+
+// buildRangeResolvers builds the resolvers of the range fields of the types and the inputs,
+// they convert the values of pgx by the adapters.
+func buildRangeResolvers(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	structs []Struct,
+	queries []Query,
+	imports *goImports,
+) []goResolver {
+	var res []goResolver
+	seen := map[string]struct{}{}
+	for _, s := range structs {
+		if _, ok := seen[s.Name]; ok {
+			continue
+		}
+		seen[s.Name] = struct{}{}
+		for _, f := range s.Fields {
+			a, ok := fieldRangeAdapter(req, options, f)
+			if !ok {
+				continue
+			}
+			res = append(
+				res, goResolver{
+					Receiver:   s.Name + "Delegate",
+					Name:       goName(gqlFieldName(f.Name, options.Naming.FieldCase)),
+					FieldName:  gqlFieldName(f.Name, options.Naming.FieldCase),
+					Args:       []goArgument{{Name: "obj", Type: "*" + imports.Model(s.ModelPath)}},
+					ReturnType: a.OutputType(imports),
+					Body:       []string{"return " + a.From(imports, "obj."+rangeGoField(f, options))},
+				},
+			)
+		}
+	}
+	for _, q := range queries {
+		if !q.Arg.EmitStruct() || !hasGoResolver(q) {
+			continue
+		}
+		name := q.Arg.DefineType()
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		// the input with the sort keys or the omittable fields is declared next to the resolvers and embeds the params struct
+		obj := name
+		if !q.wrapsParams() {
+			obj = imports.Model(q.Arg.ModelPath)
+		}
+		for _, f := range q.Arg.Struct.Fields {
+			a, ok := fieldRangeAdapter(req, options, f)
+			if !ok {
+				continue
+			}
+			res = append(
+				res, goResolver{
+					Receiver:  name + "Delegate",
+					Name:      goName(gqlFieldName(f.Name, options.Naming.FieldCase)),
+					FieldName: gqlFieldName(f.Name, options.Naming.FieldCase),
+					Args: []goArgument{
+						{Name: "obj", Type: "*" + obj},
+						{Name: "data", Type: a.InputType(imports, f.Type)},
+					},
+					Body: []string{
+						"obj." + rangeGoField(f, options) + ", err = " + a.To(imports, "data"),
+						"return err",
+					},
+				},
+			)
+		}
+	}
+	return res
+}
+
+func fieldRangeAdapter(req *plugin.GenerateRequest, options *opts.Options, f Field) (rangeAdapter, bool) {
+	if f.Column == nil || !isRangeType(f.Type) {
+		return rangeAdapter{}, false
+	}
+	return newRangeAdapter(goType(req, options, f.Column))
+}
+
+// rangeGoField returns the name of the range field in the struct of sqlc-gen-go,
+// the fields of the models are named by their columns.
+func rangeGoField(f Field, options *opts.Options) string {
+	if f.DBName == "" {
+		f.DBName = f.Column.Name
+	}
+	return goFieldName(f, options)
+}
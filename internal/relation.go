@@ -0,0 +1,192 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/inflection"
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// Loader loads the rows of a table by the values of one column in a single query.
+type Loader struct {
+	Name      string
+	ModelPath string
+	Table     *plugin.Identifier
+	Key       *plugin.Column
+	// KeyNotNull is the nullability of the column of the other side passing the key to the loader
+	KeyNotNull bool
+	Columns    []*plugin.Column
+	// Many is true if several rows can have the same key
+	Many bool
+}
+
+type relationEnd struct {
+	structIdx int
+	table     *plugin.Table
+	column    *plugin.Column
+}
+
+// addRelationFields adds the object fields for the foreign keys set in the relations option,
+// and the list fields for the reverse side of them. The fields are resolved by the loaders.
+func addRelationFields(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	structs []Struct,
+) ([]Struct, []Loader, error) {
+	var loaders []Loader
+	var addLoader func(l Loader)
+	addLoader = func(l Loader) {
+		for i, loader := range loaders {
+			if loader.Name != l.Name {
+				continue
+			}
+			if goType(req, options, loaderKey(loader)) == goType(req, options, loaderKey(l)) {
+				return
+			}
+			// the same rows are loaded by the nullable and the not null keys of different Go types
+			if loader.KeyNotNull {
+				l.Name = nullableLoaderName(l.Name)
+				addLoader(l)
+				return
+			}
+			loaders[i].Name = nullableLoaderName(loader.Name)
+		}
+		loaders = append(loaders, l)
+	}
+
+	for _, relation := range options.Relations {
+		from, err := findRelationEnd(req, structs, relation.From)
+		if err != nil {
+			return nil, nil, fmt.Errorf("relation from %s to %s: %w", relation.From, relation.To, err)
+		}
+		to, err := findRelationEnd(req, structs, relation.To)
+		if err != nil {
+			return nil, nil, fmt.Errorf("relation from %s to %s: %w", relation.From, relation.To, err)
+		}
+		fromStruct := &structs[from.structIdx]
+		toStruct := &structs[to.structIdx]
+
+		field := relation.Field
+		if field == "" {
+			name, ok := strings.CutSuffix(from.column.Name, "_id")
+			if !ok {
+				return nil, nil, fmt.Errorf(
+					"relation from %s to %s: the field name should be set for the column without the _id suffix",
+					relation.From, relation.To,
+				)
+			}
+			field = argName(name)
+		}
+		fieldType := toStruct.Name
+		if from.column.NotNull {
+			fieldType += "!"
+		}
+		fromStruct.Fields = append(
+			fromStruct.Fields, Field{
+				Name:      StructName(field, options),
+				Type:      fieldType,
+				Directive: "@goField(forceResolver: true)",
+				Relation:  true,
+			},
+		)
+		addLoader(
+			Loader{
+				Name:       goModelName(toStruct.ModelPath) + loaderKeySuffix(to.column) + "Loader",
+				ModelPath:  toStruct.ModelPath,
+				Table:      toStruct.Table,
+				Key:        to.column,
+				KeyNotNull: from.column.NotNull,
+				Columns:    to.table.Columns,
+			},
+		)
+
+		if relation.ReverseField == "-" {
+			continue
+		}
+		reverseField := relation.ReverseField
+		if reverseField == "" {
+			reverseField = toLowerCase(inflection.Plural(goModelName(fromStruct.ModelPath)))
+		}
+		toStruct.Fields = append(
+			toStruct.Fields, Field{
+				Name:      StructName(reverseField, options),
+				Type:      "[" + fromStruct.Name + "!]!",
+				Directive: "@goField(forceResolver: true)",
+				Relation:  true,
+			},
+		)
+		addLoader(
+			Loader{
+				Name:       inflection.Plural(goModelName(fromStruct.ModelPath)) + "By" + goStructName(from.column.Name) + "Loader",
+				ModelPath:  fromStruct.ModelPath,
+				Table:      fromStruct.Table,
+				Key:        from.column,
+				KeyNotNull: to.column.NotNull,
+				Columns:    from.table.Columns,
+				Many:       true,
+			},
+		)
+	}
+	return structs, loaders, nil
+}
+
+// nullableLoaderName names the loader taking the nullable keys, e.g. AuthorNullableLoader.
+func nullableLoaderName(name string) string {
+	return strings.TrimSuffix(name, "Loader") + "NullableLoader"
+}
+
+// loaderKeySuffix names the loaders of the rows found not by the id column.
+func loaderKeySuffix(key *plugin.Column) string {
+	if key.Name == "id" {
+		return ""
+	}
+	return "By" + goStructName(key.Name)
+}
+
+// findRelationEnd finds the table column written as table.column or schema.table.column.
+func findRelationEnd(req *plugin.GenerateRequest, structs []Struct, name string) (*relationEnd, error) {
+	parts := strings.Split(name, ".")
+	schemaName := req.Catalog.DefaultSchema
+	switch len(parts) {
+	case 2:
+	case 3:
+		schemaName = parts[0]
+		parts = parts[1:]
+	default:
+		return nil, fmt.Errorf("invalid column %q, it should be in the format of 'table.column'", name)
+	}
+	tableName, columnName := parts[0], parts[1]
+
+	for i, s := range structs {
+		if s.Table == nil || s.Table.Schema != schemaName || s.Table.Name != tableName {
+			continue
+		}
+		table := findTable(req, s.Table)
+		if table == nil {
+			break
+		}
+		for _, c := range table.Columns {
+			if c.Name == columnName {
+				return &relationEnd{structIdx: i, table: table, column: c}, nil
+			}
+		}
+		return nil, fmt.Errorf("column %s is not found in the table %s", columnName, tableName)
+	}
+	return nil, fmt.Errorf("table %s is not found in the schema %s", tableName, schemaName)
+}
+
+func findTable(req *plugin.GenerateRequest, ident *plugin.Identifier) *plugin.Table {
+	for _, schema := range req.Catalog.Schemas {
+		if schema.Name != ident.Schema {
+			continue
+		}
+		for _, table := range schema.Tables {
+			if table.Rel.Name == ident.Name {
+				return table
+			}
+		}
+	}
+	return nil
+}
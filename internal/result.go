@@ -2,6 +2,7 @@ package golang
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -26,9 +27,11 @@ func buildEnums(req *plugin.GenerateRequest, options *opts.Options) []Enum {
 				enumName = schema.Name + "_" + enum.Name
 			}
 
+			goName := StructName(enumName, options)
 			e := Enum{
-				Name:    StructName(enumName, options),
-				Comment: enum.Comment,
+				Name:      gqlTypeName(options, goName),
+				Comment:   enum.Comment,
+				ModelPath: options.Package + "." + goName,
 			}
 
 			seen := make(map[string]struct{}, len(enum.Vals))
@@ -80,21 +83,25 @@ func buildStructs(req *plugin.GenerateRequest, options *opts.Options) []Struct {
 			modelName := StructName(structName, options)
 			s := Struct{
 				Table:   &plugin.Identifier{Schema: schema.Name, Name: table.Rel.Name},
-				Name:    modelName,
+				Name:    gqlTypeName(options, modelName),
 				Comment: table.Comment,
 			}
-			s.ModelPath = options.Package + "." + s.Name
+			s.ModelPath = options.Package + "." + modelName
 			for _, column := range table.Columns {
 				fieldName := StructName(column.Name, options)
+				deprecated, comment := parseDeprecatedComment(column.Comment)
 				s.Fields = append(
 					s.Fields, Field{
-						Name:      fieldName,
-						Type:      gqlType(req, options, column),
-						Comment:   column.Comment,
-						Directive: parseDirective(options.Directives, modelName, fieldName),
+						Name:       fieldName,
+						Type:       gqlType(req, options, column),
+						Comment:    comment,
+						Column:     column,
+						Directive:  parseDirective(options.Directives, s.Name, fieldName),
+						Deprecated: deprecated,
 					},
 				)
 			}
+			s.Fields = addRangeResolvers(s.Fields)
 			structs = append(structs, s)
 		}
 	}
@@ -141,7 +148,7 @@ func newGoEmbed(embed *plugin.Identifier, structs []Struct, defaultSchema string
 
 		return &goEmbed{
 			modelType: s.Name,
-			modelName: s.Name,
+			modelName: goModelName(s.ModelPath),
 			fields:    fields,
 		}
 	}
@@ -188,50 +195,96 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 		}
 
 		comments := query.Comments
-		var extendedType string
-		resolverName := query.Name
-		returnType := ""
-		directive := ""
+		var sig *gqlSignature
 		for i, comment := range comments {
-			parts := strings.Split(comment, ":")
-			if len(parts) > 1 && strings.Trim(parts[0], " ") == "gql" {
-				gql := strings.Trim(parts[1], " ")
-				resolverInfo := strings.Split(gql, ".")
-				extendedType = resolverInfo[0]
-				if len(resolverInfo) > 1 {
-					resolverName = resolverInfo[1]
-				}
-				if len(parts) > 2 {
-					returnType = strings.TrimSpace(parts[2])
-					directiveParts := strings.Split(returnType, "@")
-					if len(directiveParts) > 1 {
-						returnType = strings.TrimSpace(directiveParts[0])
-						directive = "@" + strings.Join(directiveParts[1:], "@")
-					}
-				}
-				comments = append(comments[:i], comments[i+1:]...)
-				break
+			text, ok := strings.CutPrefix(strings.TrimSpace(comment), "gql:")
+			if !ok {
+				continue
+			}
+			var err error
+			sig, err = parseGqlSignature(text)
+			if err != nil {
+				return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
 			}
+			comments = append(comments[:i], comments[i+1:]...)
+			break
 		}
-		if extendedType == "" {
+		if sig == nil {
 			continue
 		}
+		if _, ok := supportedCmds[query.Cmd]; !ok {
+			return nil, fmt.Errorf(
+				"%s: query %s: the %s command is not supported in GraphQL",
+				query.Filename, query.Name, query.Cmd,
+			)
+		}
+		extendedType := sig.ExtendedType
+		resolverName := query.Name
+		if sig.FieldName() != "" {
+			resolverName = sig.FieldName()
+		}
+		returnType := baseType(sig.ReturnType())
+		directive := sig.Directive()
 
-		paginated := false
-		cursorPagination := false
-		for i, comment := range comments {
-			comment = strings.TrimSpace(comment)
-			if strings.HasPrefix(comment, "paginated") {
-				paginated = true
-				comments = append(comments[:i], comments[i+1:]...)
-				if strings.Contains(comment, "cursor") {
-					cursorPagination = true
-				}
-				break
-			}
+		paginated, cursorPagination, cursorOrder, comments, err := parsePagination(comments)
+		if err != nil {
+			return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+		}
+		if err := checkCursorColumns(cursorOrder, query.Columns); err != nil {
+			return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+		}
+		sortColumns, comments, err := parseSort(comments, query.Columns)
+		if err != nil {
+			return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+		}
+		if len(sortColumns) > 0 && (!paginated || query.Cmd != metadata.CmdMany) {
+			return nil, fmt.Errorf("%s: query %s: gql-sort requires the paginated :many query", query.Filename, query.Name)
+		}
+		// the cursor of the row is made of the values of the cursor columns, so the rows are ordered by them only
+		if len(sortColumns) > 0 && cursorPagination {
+			return nil, fmt.Errorf(
+				"%s: query %s: gql-sort requires the offset pagination, the cursor pagination is ordered by the cursor columns",
+				query.Filename, query.Name,
+			)
+		}
+		if len(sortColumns) > 0 && !strings.Contains(query.Text, orderByPlaceholder) {
+			return nil, fmt.Errorf(
+				"%s: query %s: gql-sort requires the %s placeholder before the default sort keys of the query",
+				query.Filename, query.Name, orderByPlaceholder,
+			)
+		}
+		filterColumns, comments, err := parseFilter(req, options, comments, query.Columns)
+		if err != nil {
+			return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+		}
+		if len(filterColumns) > 0 && query.Cmd != metadata.CmdMany {
+			return nil, fmt.Errorf("%s: query %s: gql-filter requires the :many query", query.Filename, query.Name)
+		}
+		// the predicate of the filter is compiled with the $N parameters and the functions of PostgreSQL
+		if len(filterColumns) > 0 && req.Settings.Engine != "postgresql" {
+			return nil, fmt.Errorf(
+				"%s: query %s: gql-filter is supported only by the postgresql engine, not by %s",
+				query.Filename, query.Name, req.Settings.Engine,
+			)
+		}
+		if len(filterColumns) > 0 && !strings.Contains(query.Text, filterPlaceholder) {
+			return nil, fmt.Errorf(
+				"%s: query %s: gql-filter requires the %s placeholder in the condition of the query",
+				query.Filename, query.Name, filterPlaceholder,
+			)
 		}
 
+		hidden, comments, err := parseHiddenParams(req, options, query, comments)
+		if err != nil {
+			return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+		}
+		notify, comments := parseNotify(comments)
+		deprecated, comments := parseDeprecated(comments)
+
 		parsedDirective := parseDirective(options.Directives, extendedType, resolverName)
+		if err := sig.checkDirectives(parsedDirective); err != nil {
+			return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+		}
 		if parsedDirective != "" {
 			if directive != "" {
 				directive += " " + parsedDirective
@@ -249,10 +302,17 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			Directive:        directive,
 			Paginated:        paginated,
 			CursorPagination: cursorPagination,
+			CursorOrder:      cursorOrder,
+			Notify:           notify,
+			Deprecated:       deprecated,
 		}
 
 		if returnType == "" {
-			returnType = gq.MethodName + "Row"
+			rowName, err := typeName("row", options.Naming.Row, gq.MethodName)
+			if err != nil {
+				return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+			}
+			returnType = gqlTypeName(options, rowName)
 		}
 
 		qpl := int(*options.QueryParameterLimit)
@@ -260,12 +320,15 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 		if paginated {
 			number := int32(len(query.Params) + 1)
 			if cursorPagination {
+				// the backward page is requested by the last and before fields alone,
+				// so the fields of the forward pagination become optional
+				forwardRequired := !options.EmitBackwardPagination
 				query.Params = append(
 					query.Params, &plugin.Parameter{
 						Number: number,
 						Column: &plugin.Column{
 							Name:         "first",
-							NotNull:      true,
+							NotNull:      forwardRequired,
 							IsNamedParam: true,
 							Type: &plugin.Identifier{
 								Name: "int",
@@ -275,7 +338,7 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 						Number: number + 1,
 						Column: &plugin.Column{
 							Name:         "after",
-							NotNull:      true,
+							NotNull:      forwardRequired,
 							IsNamedParam: true,
 							Type: &plugin.Identifier{
 								Name: "string",
@@ -283,6 +346,31 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 						},
 					},
 				)
+				// the params struct of sqlc-gen-go has only the limit and the cursor of the forward pagination,
+				// so the backward one is emitted for the params struct reading the last and before fields
+				if options.EmitBackwardPagination {
+					query.Params = append(
+						query.Params, &plugin.Parameter{
+							Number: number + 2,
+							Column: &plugin.Column{
+								Name:         "last",
+								IsNamedParam: true,
+								Type: &plugin.Identifier{
+									Name: "int",
+								},
+							},
+						}, &plugin.Parameter{
+							Number: number + 3,
+							Column: &plugin.Column{
+								Name:         "before",
+								IsNamedParam: true,
+								Type: &plugin.Identifier{
+									Name: "string",
+								},
+							},
+						},
+					)
+				}
 			} else {
 				query.Params = append(
 					query.Params, &plugin.Parameter{
@@ -315,7 +403,7 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			gq.Arg = QueryValue{
 				Name:   escape(paramName(p)),
 				DBName: p.Column.GetName(),
-				Typ:    gqlType(req, options, p.Column),
+				Typ:    rangeInputType(gqlType(req, options, p.Column)),
 				Column: p.Column,
 			}
 		} else if len(query.Params) >= 1 {
@@ -328,11 +416,16 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 					},
 				)
 			}
-			s, err := columnsToStruct(req, options, resolverName+"Input", cols, false)
+			name, err := inputName(options, resolverName)
+			if err != nil {
+				return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+			}
+			s, err := columnsToStruct(req, options, name, cols, false)
 			if err != nil {
 				return nil, err
 			}
 			s.Fields = addDefaultDirectivesToPaginationInputFields(s.Fields)
+			s.Fields = addRangeInputFields(s.Fields)
 			gq.Arg = QueryValue{
 				Emit:      true,
 				Name:      "request",
@@ -345,6 +438,24 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			}
 		}
 
+		if isBulkCmd(query.Cmd) && !gq.Arg.isEmpty() {
+			if len(hidden) > 0 {
+				return nil, fmt.Errorf(
+					"%s: query %s: hidden params are not supported by the %s command",
+					query.Filename, query.Name, query.Cmd,
+				)
+			}
+			// the query is executed for every item of the list,
+			// and sqlc-gen-go always takes a slice of the params struct
+			gq.Arg.List = true
+			gq.Arg.Emit = gq.Arg.IsStruct()
+		}
+
+		gq.Arg, gq.Hidden = hideParams(gq.Arg, hidden, options)
+		for _, h := range gq.Hidden {
+			gq.Directive = strings.TrimSpace(gq.Directive + " " + h.Directive())
+		}
+
 		if len(query.Columns) == 1 && query.Columns[0].EmbedTable == nil {
 			c := query.Columns[0]
 			name := columnName(c, 0)
@@ -354,6 +465,7 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 				DBName:    name,
 				Typ:       gqlType(req, options, c),
 				ModelPath: options.Package + "." + gq.MethodName,
+				Column:    c,
 			}
 		} else if putOutColumns(query) {
 			var gs *Struct
@@ -381,7 +493,7 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			}
 
 			modelPath := options.Package + "." + gq.MethodName
-			if paginated && gs != nil {
+			if gs != nil {
 				modelPath = gs.ModelPath
 			}
 			if gs == nil {
@@ -400,6 +512,8 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 				if err != nil {
 					return nil, err
 				}
+				gs.Fields = addRangeResolvers(gs.Fields)
+				gs.Fields = deprecateColumns(gs.Fields)
 				emit = true
 				modelPath = options.Package + "." + gq.MethodName + "Row"
 			}
@@ -414,12 +528,100 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			}
 		}
 
+		// the params struct of the query has no field for the sort keys,
+		// so the input is bound to the struct embedding it generated next to the resolvers
+		if len(sortColumns) > 0 {
+			if !gq.Ret.IsStruct() || !gq.Arg.IsStruct() {
+				return nil, fmt.Errorf(
+					"%s: query %s: gql-sort requires the query returning rows and taking the input",
+					query.Filename, query.Name,
+				)
+			}
+			if options.ResolverPackage == "" {
+				return nil, fmt.Errorf(
+					"%s: query %s: gql-sort requires the resolver_package option to declare the input with the sort keys",
+					query.Filename, query.Name,
+				)
+			}
+			gq.Sort = &Sort{Name: gq.Ret.Struct.Name, Columns: sortColumns}
+			gq.Arg.Emit = true
+			gq.Arg.ModelPath = options.ResolverPackage + "." + gq.Arg.DefineType()
+		}
+
+		// the params struct has no Omittable fields, so the input is bound to the struct shadowing them
+		if options.EmitOmittableParams && gq.Arg.EmitStruct() && !gq.Arg.List {
+			gq.Arg.Struct.Fields = addOmittableInputFields(gq.Arg.Struct.Fields, paginated && cursorPagination)
+			if gq.Arg.hasOmittableFields() {
+				gq.Arg.ModelPath = options.ResolverPackage + "." + gq.Arg.DefineType()
+			}
+		}
+
+		// the filter is the argument of the field next to the input, as the params struct has no field for it
+
+		if len(filterColumns) > 0 {
+			if !gq.Ret.IsStruct() {
+				return nil, fmt.Errorf("%s: query %s: gql-filter requires the query returning rows", query.Filename, query.Name)
+			}
+			gq.Filter = &Filter{Name: gq.Ret.Struct.Name, Columns: filterColumns}
+		}
+
+		if paginated && gq.Ret.IsStruct() {
+			var err error
+			if cursorPagination {
+				gq.PageName, err = typeName("connection", options.Naming.Connection, gq.Ret.Struct.Name)
+				if err == nil {
+					gq.EdgeName, err = typeName("edge", options.Naming.Edge, gq.Ret.Struct.Name)
+				}
+			} else {
+				gq.PageName, err = typeName("page", options.Naming.Page, gq.Ret.Struct.Name)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+			}
+		}
+		gq.Arg.FieldCase = options.Naming.FieldCase
+
+		if err := checkNotify(gq, sig); err != nil {
+			return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+		}
+		if err := sig.apply(&gq); err != nil {
+			return nil, fmt.Errorf("%s: query %s: %w", query.Filename, query.Name, err)
+		}
+
 		qs = append(qs, gq)
 	}
 	sort.Slice(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
+	if err := emitSortInputs(qs); err != nil {
+		return nil, err
+	}
+	if err := emitFilterInputs(qs); err != nil {
+		return nil, err
+	}
 	return qs, nil
 }
 
+var supportedCmds = map[string]struct{}{
+	metadata.CmdBatchExec:  {},
+	metadata.CmdBatchMany:  {},
+	metadata.CmdBatchOne:   {},
+	metadata.CmdCopyFrom:   {},
+	metadata.CmdExec:       {},
+	metadata.CmdExecLastId: {},
+	metadata.CmdExecResult: {},
+	metadata.CmdExecRows:   {},
+	metadata.CmdMany:       {},
+	metadata.CmdOne:        {},
+}
+
+// isBulkCmd reports whether the query takes a list of params.
+func isBulkCmd(cmd string) bool {
+	switch cmd {
+	case metadata.CmdBatchExec, metadata.CmdBatchMany, metadata.CmdBatchOne, metadata.CmdCopyFrom:
+		return true
+	}
+	return false
+}
+
 var cmdReturnsData = map[string]struct{}{
 	metadata.CmdBatchMany: {},
 	metadata.CmdBatchOne:  {},
@@ -545,9 +747,9 @@ func addRetValuesToStructs(structs []Struct, queries []Query) []Struct {
 			}
 			if q.Paginated {
 				if q.CursorPagination {
-					structs = addConnectionStruct(*q.Ret.Struct, structs)
+					structs = addConnectionStruct(*q.Ret.Struct, q.PageName, q.EdgeName, structs)
 				} else {
-					structs = addPageStruct(*q.Ret.Struct, structs)
+					structs = addPageStruct(*q.Ret.Struct, q.PageName, structs)
 				}
 			}
 		}
@@ -555,10 +757,11 @@ func addRetValuesToStructs(structs []Struct, queries []Query) []Struct {
 	return structs
 }
 
-func addPageStruct(original Struct, structs []Struct) []Struct {
-	pageName := original.Name + "Page"
+// addPageStruct adds the page of the items once for all the queries returning them,
+// the pages of different items named the same are reported by validate.
+func addPageStruct(original Struct, pageName string, structs []Struct) []Struct {
 	for _, s := range structs {
-		if s.Name == pageName {
+		if s.Name == pageName && s.ModelPath == original.ModelPath+"Page" {
 			return structs
 		}
 	}
@@ -594,11 +797,9 @@ func addPageStruct(original Struct, structs []Struct) []Struct {
 	return structs
 }
 
-func addConnectionStruct(original Struct, structs []Struct) []Struct {
-	connectionName := original.Name + "Connection"
-	edgeName := original.Name + "Edge"
+func addConnectionStruct(original Struct, connectionName, edgeName string, structs []Struct) []Struct {
 	for _, s := range structs {
-		if s.Name == connectionName {
+		if s.Name == connectionName && s.ModelPath == original.ModelPath+"Connection" {
 			return structs
 		}
 	}
@@ -696,3 +897,19 @@ func addDefaultDirectivesToPaginationInputFields(fields []Field) []Field {
 	}
 	return res
 }
+
+// addOmittableInputFields marks nullable input fields as omittable,
+// so gqlgen can tell a field that was not sent apart from a field sent as null.
+// The fields of the cursor pagination and the ranges resolved by the delegates are kept as is.
+func addOmittableInputFields(fields []Field, cursorPagination bool) []Field {
+	res := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if cursorPagination && slices.Contains([]string{"First", "After", "Last", "Before"}, f.Name) {
+			res = append(res, f)
+			continue
+		}
+		f.Omittable = !strings.HasSuffix(f.Type, "!") && !strings.Contains(f.Directive, "@goField")
+		res = append(res, f)
+	}
+	return res
+}
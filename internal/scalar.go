@@ -0,0 +1,184 @@
+package golang
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// Scalar is a custom scalar produced by the type mapping.
+// Its Go models are the marshalers of the schema package or of gqlgen.
+type Scalar struct {
+	Name   string
+	Models []string
+	// Builtin scalars are extended with the models of the pgtype wrappers
+	Builtin bool
+}
+
+var knownScalars = []Scalar{
+	{Name: "Boolean", Models: []string{"github.com/99designs/gqlgen/graphql.Boolean"}, Builtin: true},
+	{Name: "Float", Models: []string{"github.com/99designs/gqlgen/graphql.FloatContext"}, Builtin: true},
+	{
+		Name: "Int",
+		Models: []string{
+			"github.com/99designs/gqlgen/graphql.Int",
+			"github.com/99designs/gqlgen/graphql.Int32",
+			"github.com/99designs/gqlgen/graphql.Int64",
+		},
+		Builtin: true,
+	},
+	{Name: "String", Models: []string{"github.com/99designs/gqlgen/graphql.String"}, Builtin: true},
+	{Name: "BigInt", Models: []string{schemaPackage + ".BigInt", schemaPackage + ".NullBigInt"}},
+	{Name: "Decimal", Models: []string{schemaPackage + ".Decimal", schemaPackage + ".NullDecimal"}},
+	{Name: "Int64", Models: []string{schemaPackage + ".Int64", schemaPackage + ".NullInt64"}},
+	{
+		Name:   "JSON",
+		Models: []string{schemaPackage + ".JSON", schemaPackage + ".JSONBytes", schemaPackage + ".NullRawMessage"},
+	},
+	{Name: "Time", Models: []string{"github.com/99designs/gqlgen/graphql.Time", schemaPackage + ".NullTime"}},
+	{Name: "UUID", Models: []string{schemaPackage + ".UUID", schemaPackage + ".NullUUID"}},
+	{Name: "Unknown", Models: []string{schemaPackage + ".Unknown"}},
+}
+
+// usedScalars collects the scalars referenced by the generated types and fields.
+// Time is always declared, because the schemas written by hand rely on it.
+// The builtin scalars are declared only to bind them to the marshalers of the pgtype wrappers.
+func usedScalars(
+	structs []Struct,
+	queries []Query,
+	ranges []RangeType,
+	marshalers []pgtypeMarshaler,
+	marshalPackage string,
+) []Scalar {
+	used := usedTypes(structs, queries)
+	used["Time"] = struct{}{}
+	for _, r := range ranges {
+		used[r.Bound] = struct{}{}
+	}
+
+	var scalars []Scalar
+	for _, s := range knownScalars {
+		_, ok := used[s.Name]
+		models := slices.Clone(s.Models)
+		for _, m := range marshalers {
+			if m.Scalar == s.Name {
+				models = append(models, marshalPackage+"."+m.Name)
+			}
+		}
+		if s.Builtin {
+			ok = len(models) > len(s.Models)
+		}
+		if ok {
+			s.Models = models
+			scalars = append(scalars, s)
+		}
+	}
+	return scalars
+}
+
+// usedTypes collects the base types of the generated fields and arguments.
+func usedTypes(structs []Struct, queries []Query) map[string]struct{} {
+	used := map[string]struct{}{}
+	add := func(typ string) {
+		used[baseType(typ)] = struct{}{}
+	}
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			add(f.Type)
+		}
+	}
+	for _, q := range queries {
+		add(q.FieldType())
+		for _, arg := range q.Arg.Pairs() {
+			add(arg.Type)
+		}
+		for _, arg := range q.Arg.Declared {
+			add(arg.Type)
+		}
+		for _, value := range []QueryValue{q.Arg, q.Ret} {
+			if value.IsStruct() {
+				for _, f := range value.Struct.Fields {
+					add(f.Type)
+				}
+			}
+		}
+	}
+	return used
+}
+
+// int64Warnings lists the 64-bit integer columns of the emitted types and arguments
+// that are still mapped to the 32-bit Int scalar, the values out of its range fail at runtime.
+// The fields hidden by the exclude options are not emitted, so they are skipped.
+func int64Warnings(
+	req *plugin.GenerateRequest,
+	options *opts.Options,
+	structs []Struct,
+	queries []Query,
+	excludedFields fieldExclusions,
+) []string {
+	var warnings []string
+	seen := map[string]struct{}{}
+	check := func(name string, col *plugin.Column) {
+		if col == nil || col.Type == nil {
+			return
+		}
+		if col.Table != nil {
+			name = col.Table.Name + "." + col.Name
+		}
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		goType := strings.TrimPrefix(goInnerType(req, options, col), "*")
+		if rangeType, ok := gqlRangeType(goType); ok && options.Int64Scalar == "" {
+			for _, r := range rangeTypes {
+				if r.Int64 && r.Name == baseType(rangeType) {
+					warnings = append(
+						warnings,
+						"the 64-bit integer range column "+name+" has the bounds of the 32-bit Int scalar, "+
+							"set the int64_scalar option to Int64 or BigInt",
+					)
+				}
+			}
+			return
+		}
+		if _, ok := int64Types[goType]; !ok {
+			return
+		}
+		if baseType(gqlType(req, options, col)) != "Int" {
+			return
+		}
+		warnings = append(
+			warnings,
+			"the 64-bit integer column "+name+" is mapped to the 32-bit Int scalar, "+
+				"set the int64_scalar option to Int64 or BigInt",
+		)
+	}
+	checkValue := func(q Query, v QueryValue, input bool) {
+		if !v.IsStruct() {
+			name := v.DBName
+			if name == "" {
+				name = v.Name
+			}
+			check(q.MethodName+"."+name, v.Column)
+			return
+		}
+		for _, s := range filterStructs([]Struct{*v.Struct}, excludedFields, options.Naming.FieldCase, input) {
+			for _, f := range s.Fields {
+				check(q.MethodName+"."+f.DBName, f.Column)
+			}
+		}
+	}
+	for _, s := range filterStructs(structs, excludedFields, options.Naming.FieldCase, false) {
+		for _, f := range s.Fields {
+			check(s.Name+"."+f.DBName, f.Column)
+		}
+	}
+	for _, q := range queries {
+		checkValue(q, q.Ret, false)
+		checkValue(q, q.Arg, true)
+	}
+	return warnings
+}
@@ -0,0 +1,112 @@
+package golang
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+func TestInt64Warnings(t *testing.T) {
+	table := &plugin.Identifier{Schema: "public", Name: "posts"}
+	id := &plugin.Column{Name: "id", NotNull: true, Table: table, Type: &plugin.Identifier{Name: "bigserial"}}
+	likes := &plugin.Column{Name: "likes", Table: table, Type: &plugin.Identifier{Name: "int8"}}
+	count := &plugin.Column{Name: "count", NotNull: true, Type: &plugin.Identifier{Name: "bigint"}}
+	title := &plugin.Column{Name: "title", Table: table, Type: &plugin.Identifier{Name: "text"}}
+	pages := &plugin.Column{Name: "pages", Table: table, Type: &plugin.Identifier{Name: "int8range"}}
+	audit := &plugin.Identifier{Schema: "public", Name: "audit_logs"}
+	position := &plugin.Column{Name: "position", NotNull: true, Table: audit, Type: &plugin.Identifier{Name: "int8"}}
+	req := &plugin.GenerateRequest{
+		Settings: &plugin.Settings{Engine: "postgresql"},
+		Catalog: &plugin.Catalog{
+			DefaultSchema: "public",
+			Schemas: []*plugin.Schema{
+				{
+					Name: "public",
+					Tables: []*plugin.Table{
+						{Rel: table, Columns: []*plugin.Column{id, likes, title, pages}},
+						{Rel: audit, Columns: []*plugin.Column{position}},
+					},
+				},
+			},
+		},
+	}
+	queries := []Query{
+		{
+			MethodName: "CountPosts",
+			Ret:        QueryValue{Name: "count", DBName: "count", Column: count},
+			Arg:        QueryValue{Name: "likes", DBName: "likes", Column: likes},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		options opts.Options
+		want    []string
+	}{
+		{
+			name:    "int",
+			options: opts.Options{},
+			want: []string{
+				"the 64-bit integer column posts.id is mapped to the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+				"the 64-bit integer column posts.likes is mapped to the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+				"the 64-bit integer column CountPosts.count is mapped to the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+			},
+		},
+		{
+			name:    "int range",
+			options: opts.Options{SqlPackage: "pgx/v5"},
+			want: []string{
+				"the 64-bit integer column posts.id is mapped to the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+				"the 64-bit integer column posts.likes is mapped to the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+				"the 64-bit integer range column posts.pages has the bounds of the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+				"the 64-bit integer column CountPosts.count is mapped to the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+			},
+		},
+		{
+			name:    "bigint range",
+			options: opts.Options{SqlPackage: "pgx/v5", Int64Scalar: "BigInt"},
+			want:    nil,
+		},
+		{
+			name:    "excluded field",
+			options: opts.Options{Exclude: []string{"Post.id"}},
+			want: []string{
+				"the 64-bit integer column posts.likes is mapped to the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+				"the 64-bit integer column CountPosts.count is mapped to the 32-bit Int scalar, set the int64_scalar option to Int64 or BigInt",
+			},
+		},
+		{
+			name:    "bigint",
+			options: opts.Options{Int64Scalar: "BigInt"},
+			want:    nil,
+		},
+		{
+			name:    "int64",
+			options: opts.Options{Int64Scalar: "Int64"},
+			want:    nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// the audit_logs table is not emitted, e.g. it is excluded by exclude_types
+			var structs []Struct
+			for _, s := range buildStructs(req, &tc.options) {
+				if s.Name != "AuditLog" {
+					structs = append(structs, s)
+				}
+			}
+			excluded, err := getGqlExcluded(&tc.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := int64Warnings(req, &tc.options, structs, queries, excluded)
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("int64Warnings() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
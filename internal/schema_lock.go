@@ -0,0 +1,380 @@
+package golang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/debugger84/sqlc-graphql/internal/opts"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const schemaLockHeader = "# Code generated by sqlc-graphql. DO NOT EDIT.\n" +
+	"# The schema lockfile, commit it to find the breaking changes of the generated schema.\n\n"
+
+type ChangeLevel string
+
+const (
+	// BreakingChange fails the queries of the existing clients
+	BreakingChange ChangeLevel = "breaking"
+	// DangerousChange keeps the queries valid, but may change the behavior of the clients
+	DangerousChange ChangeLevel = "dangerous"
+	SafeChange      ChangeLevel = "safe"
+)
+
+// SchemaChange is a difference of the generated schema from the schema lockfile.
+type SchemaChange struct {
+	Level ChangeLevel
+	// Coordinate is the changed element, e.g. Author, Author.name, Query.author(id:) or Status.ACTIVE
+	Coordinate  string
+	Description string
+}
+
+func (c SchemaChange) String() string {
+	return c.Coordinate + ": " + c.Description
+}
+
+// checkSchemaLock compares the generated schema with the lockfile and fails on the breaking changes
+// missing in the accept_breaking_changes option. The new lockfile is returned as a generated file if there are none,
+// so it is written by sqlc generate and only compared by sqlc diff.
+// The lockfile is read from the host filesystem, which is available to the process plugins only.
+func checkSchemaLock(req *plugin.GenerateRequest, options *opts.Options, files []*plugin.File) (*plugin.File, error) {
+	if err := checkSchemaLockOut(req.GetSettings().GetCodegen().GetOut(), options); err != nil {
+		return nil, err
+	}
+	generated, err := lockSchema(files)
+	if err != nil {
+		return nil, fmt.Errorf("schema lock: %w", err)
+	}
+	lock := &plugin.File{Name: options.SchemaLockOut, Contents: []byte(renderSchemaLock(generated))}
+
+	locked, err := os.ReadFile(options.SchemaLock)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(
+			os.Stderr,
+			"WARNING: the schema lockfile %s is not found, it is created at %s without checking the breaking changes\n",
+			options.SchemaLock, options.SchemaLockOut,
+		)
+		return lock, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("schema lock: %w", err)
+	}
+	if bytes.Equal(locked, lock.Contents) {
+		return lock, nil
+	}
+	lockedDoc, err := parser.ParseSchema(&ast.Source{Name: options.SchemaLock, Input: string(locked)})
+	if err != nil {
+		return nil, fmt.Errorf("schema lock: %w", err)
+	}
+
+	var breaking []string
+	accepted := map[string]bool{}
+	for _, change := range diffSchemas(lockedDoc, generated) {
+		switch change.Level {
+		case BreakingChange:
+			if slices.Contains(options.AcceptBreakingChanges, change.Coordinate) {
+				accepted[change.Coordinate] = true
+				continue
+			}
+			breaking = append(breaking, change.String())
+		case DangerousChange:
+			fmt.Fprintf(os.Stderr, "WARNING: dangerous change of the schema %s\n", change)
+		}
+	}
+	if len(breaking) > 0 {
+		return nil, fmt.Errorf(
+			"schema lock: the breaking changes are not accepted in accept_breaking_changes: %s",
+			strings.Join(breaking, "; "),
+		)
+	}
+	for _, coordinate := range options.AcceptBreakingChanges {
+		if !accepted[coordinate] {
+			fmt.Fprintf(
+				os.Stderr,
+				"WARNING: the accepted breaking change %s is not found, remove it from accept_breaking_changes\n",
+				coordinate,
+			)
+		}
+	}
+	return lock, nil
+}
+
+// checkSchemaLockOut fails if the new lockfile is generated to another file than the compared one,
+// otherwise the compared lockfile is never updated and the accepted breaking changes are reported forever.
+// The out directory is unknown without the codegen settings, e.g. in the tests, then the paths are not checked.
+func checkSchemaLockOut(out string, options *opts.Options) error {
+	if out == "" {
+		return nil
+	}
+	lock, err := filepath.Abs(options.SchemaLock)
+	if err != nil {
+		return fmt.Errorf("schema lock: %w", err)
+	}
+	lockOut, err := filepath.Abs(filepath.Join(out, filepath.FromSlash(options.SchemaLockOut)))
+	if err != nil {
+		return fmt.Errorf("schema lock: %w", err)
+	}
+	if lock != lockOut {
+		return fmt.Errorf(
+			"schema lock: schema_lock_out %s resolves to %s, but schema_lock is %s, they should be the same file",
+			options.SchemaLockOut, lockOut, lock,
+		)
+	}
+	return nil
+}
+
+// lockSchema merges the generated GraphQL files into one document with the extensions merged into the types.
+func lockSchema(files []*plugin.File) (*ast.SchemaDocument, error) {
+	var sources []*ast.Source
+	for _, file := range files {
+		if path.Ext(file.Name) == ".graphql" {
+			sources = append(sources, &ast.Source{Name: file.Name, Input: string(file.Contents)})
+		}
+	}
+	slices.SortFunc(sources, func(a, b *ast.Source) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	doc, err := parser.ParseSchemas(sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := map[string]*ast.Definition{}
+	for _, def := range append(doc.Definitions, doc.Extensions...) {
+		merged, ok := definitions[def.Name]
+		if !ok {
+			merged = &ast.Definition{Kind: def.Kind, Name: def.Name}
+			definitions[def.Name] = merged
+		}
+		if def.Description != "" {
+			merged.Description = def.Description
+		}
+		merged.Directives = append(merged.Directives, def.Directives...)
+		merged.Interfaces = append(merged.Interfaces, def.Interfaces...)
+		merged.Fields = append(merged.Fields, def.Fields...)
+		merged.Types = append(merged.Types, def.Types...)
+		merged.EnumValues = append(merged.EnumValues, def.EnumValues...)
+	}
+
+	res := &ast.SchemaDocument{}
+	for _, def := range definitions {
+		res.Definitions = append(res.Definitions, def)
+	}
+	slices.SortFunc(res.Definitions, func(a, b *ast.Definition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return res, nil
+}
+
+func renderSchemaLock(doc *ast.SchemaDocument) string {
+	var buf bytes.Buffer
+	buf.WriteString(schemaLockHeader)
+	formatter.NewFormatter(&buf, formatter.WithIndent("    ")).FormatSchemaDocument(doc)
+	return buf.String()
+}
+
+// diffSchemas classifies the changes of the types like graphql-js findBreakingChanges and findDangerousChanges.
+func diffSchemas(oldDoc, newDoc *ast.SchemaDocument) []SchemaChange {
+	var changes []SchemaChange
+	add := func(level ChangeLevel, coordinate string, format string, args ...any) {
+		changes = append(
+			changes,
+			SchemaChange{Level: level, Coordinate: coordinate, Description: fmt.Sprintf(format, args...)},
+		)
+	}
+
+	for _, oldDef := range oldDoc.Definitions {
+		newDef := newDoc.Definitions.ForName(oldDef.Name)
+		if newDef == nil {
+			add(BreakingChange, oldDef.Name, "the type is removed")
+			continue
+		}
+		if oldDef.Kind != newDef.Kind {
+			add(BreakingChange, oldDef.Name, "the type is changed from %s to %s", oldDef.Kind, newDef.Kind)
+			continue
+		}
+		switch oldDef.Kind {
+		case ast.Object, ast.Interface:
+			diffInterfaces(oldDef, newDef, add)
+			diffOutputFields(oldDef, newDef, add)
+		case ast.InputObject:
+			diffInputFields(oldDef, newDef, add)
+		case ast.Enum:
+			for _, v := range oldDef.EnumValues {
+				if newDef.EnumValues.ForName(v.Name) == nil {
+					add(BreakingChange, oldDef.Name+"."+v.Name, "the enum value is removed")
+				}
+			}
+			for _, v := range newDef.EnumValues {
+				if oldDef.EnumValues.ForName(v.Name) == nil {
+					add(DangerousChange, newDef.Name+"."+v.Name, "the enum value is added")
+				}
+			}
+		case ast.Union:
+			for _, t := range oldDef.Types {
+				if !slices.Contains(newDef.Types, t) {
+					add(BreakingChange, oldDef.Name, "the member %s is removed from the union", t)
+				}
+			}
+			for _, t := range newDef.Types {
+				if !slices.Contains(oldDef.Types, t) {
+					add(DangerousChange, newDef.Name, "the member %s is added to the union", t)
+				}
+			}
+		}
+	}
+	for _, newDef := range newDoc.Definitions {
+		if oldDoc.Definitions.ForName(newDef.Name) == nil {
+			add(SafeChange, newDef.Name, "the type is added")
+		}
+	}
+	return changes
+}
+
+type addChange func(level ChangeLevel, coordinate string, format string, args ...any)
+
+func diffInterfaces(oldDef, newDef *ast.Definition, add addChange) {
+	for _, i := range oldDef.Interfaces {
+		if !slices.Contains(newDef.Interfaces, i) {
+			add(BreakingChange, oldDef.Name, "the interface %s is not implemented anymore", i)
+		}
+	}
+	for _, i := range newDef.Interfaces {
+		if !slices.Contains(oldDef.Interfaces, i) {
+			add(DangerousChange, newDef.Name, "the interface %s is implemented", i)
+		}
+	}
+}
+
+func diffOutputFields(oldDef, newDef *ast.Definition, add addChange) {
+	for _, oldField := range oldDef.Fields {
+		coordinate := oldDef.Name + "." + oldField.Name
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			add(BreakingChange, coordinate, "the field is removed")
+			continue
+		}
+		if oldField.Type.String() != newField.Type.String() {
+			level := SafeChange
+			if !isSafeOutputChange(oldField.Type, newField.Type) {
+				level = BreakingChange
+			}
+			add(level, coordinate, "the type is changed from %s to %s", oldField.Type, newField.Type)
+		}
+
+		for _, oldArg := range oldField.Arguments {
+			argCoordinate := coordinate + "(" + oldArg.Name + ":)"
+			newArg := newField.Arguments.ForName(oldArg.Name)
+			if newArg == nil {
+				add(BreakingChange, argCoordinate, "the argument is removed")
+				continue
+			}
+			diffInputValue(argCoordinate, oldArg.Type, newArg.Type, oldArg.DefaultValue, newArg.DefaultValue, add)
+		}
+		for _, newArg := range newField.Arguments {
+			if oldField.Arguments.ForName(newArg.Name) != nil {
+				continue
+			}
+			argCoordinate := coordinate + "(" + newArg.Name + ":)"
+			if newArg.Type.NonNull && newArg.DefaultValue == nil {
+				add(BreakingChange, argCoordinate, "the required argument is added")
+			} else {
+				add(DangerousChange, argCoordinate, "the optional argument is added")
+			}
+		}
+	}
+	for _, newField := range newDef.Fields {
+		if oldDef.Fields.ForName(newField.Name) == nil {
+			add(SafeChange, newDef.Name+"."+newField.Name, "the field is added")
+		}
+	}
+}
+
+func diffInputFields(oldDef, newDef *ast.Definition, add addChange) {
+	for _, oldField := range oldDef.Fields {
+		coordinate := oldDef.Name + "." + oldField.Name
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			add(BreakingChange, coordinate, "the input field is removed")
+			continue
+		}
+		diffInputValue(coordinate, oldField.Type, newField.Type, oldField.DefaultValue, newField.DefaultValue, add)
+	}
+	for _, newField := range newDef.Fields {
+		if oldDef.Fields.ForName(newField.Name) != nil {
+			continue
+		}
+		coordinate := newDef.Name + "." + newField.Name
+		if newField.Type.NonNull && newField.DefaultValue == nil {
+			add(BreakingChange, coordinate, "the required input field is added")
+		} else {
+			add(DangerousChange, coordinate, "the optional input field is added")
+		}
+	}
+}
+
+// diffInputValue compares the argument or the input field kept in the new schema.
+func diffInputValue(coordinate string, oldType, newType *ast.Type, oldDefault, newDefault *ast.Value, add addChange) {
+	if oldType.String() != newType.String() {
+		level := SafeChange
+		if !isSafeInputChange(oldType, newType) {
+			level = BreakingChange
+		}
+		add(level, coordinate, "the type is changed from %s to %s", oldType, newType)
+	}
+	if oldDefault.String() != newDefault.String() {
+		add(
+			DangerousChange, coordinate, "the default value is changed from %s to %s",
+			defaultValue(oldDefault), defaultValue(newDefault),
+		)
+	}
+}
+
+func defaultValue(v *ast.Value) string {
+	if v == nil {
+		return "none"
+	}
+	return v.String()
+}
+
+// isSafeOutputChange reports whether the clients still get the values they expect, e.g. String to String!.
+func isSafeOutputChange(oldType, newType *ast.Type) bool {
+	if newType.NonNull && !oldType.NonNull {
+		return isSafeOutputChange(oldType, nullableType(newType))
+	}
+	if oldType.NonNull != newType.NonNull {
+		return false
+	}
+	if oldType.Elem != nil || newType.Elem != nil {
+		return oldType.Elem != nil && newType.Elem != nil && isSafeOutputChange(oldType.Elem, newType.Elem)
+	}
+	return oldType.NamedType == newType.NamedType
+}
+
+// isSafeInputChange reports whether the values sent by the clients are still valid, e.g. String! to String.
+func isSafeInputChange(oldType, newType *ast.Type) bool {
+	if oldType.NonNull && !newType.NonNull {
+		return isSafeInputChange(nullableType(oldType), newType)
+	}
+	if oldType.NonNull != newType.NonNull {
+		return false
+	}
+	if oldType.Elem != nil || newType.Elem != nil {
+		return oldType.Elem != nil && newType.Elem != nil && isSafeInputChange(oldType.Elem, newType.Elem)
+	}
+	return oldType.NamedType == newType.NamedType
+}
+
+func nullableType(t *ast.Type) *ast.Type {
+	return &ast.Type{NamedType: t.NamedType, Elem: t.Elem}
+}
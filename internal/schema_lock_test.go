@@ -0,0 +1,101 @@
+package golang
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []SchemaChange
+	}{
+		{
+			name: "removed field",
+			old:  "type Author { id: ID! name: String }",
+			new:  "type Author { id: ID! }",
+			want: []SchemaChange{{BreakingChange, "Author.name", "the field is removed"}},
+		},
+		{
+			name: "nullable to non-null field",
+			old:  "type Author { name: String }",
+			new:  "type Author { name: String! }",
+			want: []SchemaChange{{SafeChange, "Author.name", "the type is changed from String to String!"}},
+		},
+		{
+			name: "non-null to nullable field",
+			old:  "type Author { tags: [String!]! }",
+			new:  "type Author { tags: [String] }",
+			want: []SchemaChange{{BreakingChange, "Author.tags", "the type is changed from [String!]! to [String]"}},
+		},
+		{
+			name: "nullable to non-null argument",
+			old:  "type Query { authors(name: String): [ID!]! }",
+			new:  "type Query { authors(name: String!): [ID!]! }",
+			want: []SchemaChange{
+				{BreakingChange, "Query.authors(name:)", "the type is changed from String to String!"},
+			},
+		},
+		{
+			name: "added arguments",
+			old:  "type Query { authors: [ID!]! }",
+			new:  "type Query { authors(limit: Int! = 10, name: String!): [ID!]! }",
+			want: []SchemaChange{
+				{DangerousChange, "Query.authors(limit:)", "the optional argument is added"},
+				{BreakingChange, "Query.authors(name:)", "the required argument is added"},
+			},
+		},
+		{
+			name: "changed input",
+			old:  "input AuthorInput { name: String! limit: Int = 10 }",
+			new:  "input AuthorInput { name: String limit: Int = 20 bio: String! }",
+			want: []SchemaChange{
+				{SafeChange, "AuthorInput.name", "the type is changed from String! to String"},
+				{DangerousChange, "AuthorInput.limit", "the default value is changed from 10 to 20"},
+				{BreakingChange, "AuthorInput.bio", "the required input field is added"},
+			},
+		},
+		{
+			name: "changed enum values",
+			old:  "enum Status { ACTIVE INACTIVE }",
+			new:  "enum Status { ACTIVE BANNED }",
+			want: []SchemaChange{
+				{BreakingChange, "Status.INACTIVE", "the enum value is removed"},
+				{DangerousChange, "Status.BANNED", "the enum value is added"},
+			},
+		},
+		{
+			name: "removed and added types",
+			old:  "type Author { id: ID! } union Entity = Author",
+			new:  "type Post { id: ID! } input Author { id: ID! }",
+			want: []SchemaChange{
+				{BreakingChange, "Author", "the type is changed from OBJECT to INPUT_OBJECT"},
+				{BreakingChange, "Entity", "the type is removed"},
+				{SafeChange, "Post", "the type is added"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		oldDoc := mustParseSchema(t, tt.old)
+		newDoc := mustParseSchema(t, tt.new)
+
+		got := diffSchemas(oldDoc, newDoc)
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: diffSchemas() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func mustParseSchema(t *testing.T, sdl string) *ast.SchemaDocument {
+	t.Helper()
+	doc, err := parser.ParseSchema(&ast.Source{Input: sdl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
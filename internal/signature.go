@@ -0,0 +1,407 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/lexer"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// The placeholder is put into a signature without a return type
+// to make it a valid GraphQL field definition.
+const signatureTypePlaceholder = ": SqlcDefaultType"
+
+var gqlName = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*`)
+
+// gqlSignature is the field definition written in the gql comment of a query, e.g.
+//
+//	-- gql: Query.author(id: UUID!): Author! @resolver(name: "GetAuthor")
+//
+// Everything after the extended type is optional.
+type gqlSignature struct {
+	Text         string
+	ExtendedType string
+	Field        *ast.FieldDefinition
+	HasType      bool
+
+	// fieldOffset is the position of the field definition in Text,
+	// insertAt is the position of the type placeholder in the field definition
+	fieldOffset int
+	insertAt    int
+}
+
+type signatureError struct {
+	Signature string
+	Column    int
+	Message   string
+}
+
+func (e *signatureError) Error() string {
+	return fmt.Sprintf("invalid gql signature %q at column %d: %s", e.Signature, e.Column, e.Message)
+}
+
+func parseGqlSignature(text string) (*gqlSignature, error) {
+	sig := &gqlSignature{Text: strings.TrimSpace(text), insertAt: -1}
+	sig.ExtendedType = gqlName.FindString(sig.Text)
+	if sig.ExtendedType == "" {
+		return nil, sig.errorAt(1, "expected the name of the extended type")
+	}
+	rest := []rune(sig.Text[len(sig.ExtendedType):])
+	if len(rest) == 0 {
+		return sig, nil
+	}
+	if rest[0] != '.' {
+		return nil, sig.errorAt(len(sig.ExtendedType)+1, "expected \".\" after the name of the extended type")
+	}
+	sig.fieldOffset = len([]rune(sig.ExtendedType)) + 1
+	field := rest[1:]
+
+	insertAt, err := sig.findTypePosition(string(field))
+	if err != nil {
+		return nil, err
+	}
+	sig.HasType = insertAt < 0
+	if !sig.HasType {
+		sig.insertAt = insertAt
+		withType := make([]rune, 0, len(field)+len(signatureTypePlaceholder))
+		withType = append(withType, field[:insertAt]...)
+		withType = append(withType, []rune(signatureTypePlaceholder+" ")...)
+		withType = append(withType, field[insertAt:]...)
+		field = withType
+	}
+
+	doc, err := parser.ParseSchema(
+		&ast.Source{
+			Input: "type " + sig.ExtendedType + " {\n" + string(field) + "\n}",
+		},
+	)
+	if err != nil {
+		var gqlErr *gqlerror.Error
+		if errors.As(err, &gqlErr) && len(gqlErr.Locations) > 0 {
+			loc := gqlErr.Locations[0]
+			return nil, sig.errorAt(sig.column(&ast.Position{Line: loc.Line, Column: loc.Column}), gqlErr.Message)
+		}
+		return nil, sig.errorAt(1, err.Error())
+	}
+	if len(doc.Definitions) != 1 || len(doc.Definitions[0].Fields) != 1 {
+		return nil, sig.errorAt(sig.fieldOffset+1, "expected exactly one field definition")
+	}
+	sig.Field = doc.Definitions[0].Fields[0]
+	if !sig.HasType {
+		sig.Field.Type = nil
+	}
+
+	return sig, nil
+}
+
+// findTypePosition returns the position where the type placeholder should be put,
+// or -1 if the field definition already has a return type.
+func (s *gqlSignature) findTypePosition(field string) (int, error) {
+	lex := lexer.New(&ast.Source{Input: field})
+	next := func() (lexer.Token, error) {
+		tok, err := lex.ReadToken()
+		if err != nil {
+			var gqlErr *gqlerror.Error
+			if errors.As(err, &gqlErr) && len(gqlErr.Locations) > 0 {
+				return tok, s.errorAt(s.fieldOffset+gqlErr.Locations[0].Column, gqlErr.Message)
+			}
+			return tok, s.errorAt(s.fieldOffset+1, err.Error())
+		}
+		return tok, nil
+	}
+
+	tok, err := next()
+	if err != nil {
+		return 0, err
+	}
+	if tok.Kind != lexer.Name {
+		return 0, s.errorAt(s.fieldOffset+tok.Pos.Column, "expected the name of the field, found "+tok.String())
+	}
+	tok, err = next()
+	if err != nil {
+		return 0, err
+	}
+	if tok.Kind == lexer.ParenL {
+		// directives of the arguments can have own parentheses
+		for depth := 1; depth > 0 && tok.Kind != lexer.EOF; {
+			if tok, err = next(); err != nil {
+				return 0, err
+			}
+			switch tok.Kind {
+			case lexer.ParenL:
+				depth++
+			case lexer.ParenR:
+				depth--
+			}
+		}
+		if tok.Kind == lexer.EOF {
+			return 0, s.errorAt(s.fieldOffset+tok.Pos.Column, "expected \")\", found <EOF>")
+		}
+		if tok, err = next(); err != nil {
+			return 0, err
+		}
+	}
+	if tok.Kind == lexer.Colon {
+		return -1, nil
+	}
+
+	return tok.Pos.Start, nil
+}
+
+// column converts a position in the parsed field definition
+// to the column in the text of the signature.
+func (s *gqlSignature) column(pos *ast.Position) int {
+	if pos == nil {
+		return 1
+	}
+	if pos.Line != 2 {
+		if pos.Line < 2 {
+			return s.fieldOffset + 1
+		}
+		return len([]rune(s.Text)) + 1
+	}
+	offset := pos.Column - 1
+	if s.insertAt >= 0 && offset > s.insertAt {
+		offset -= len(signatureTypePlaceholder) + 1
+		if offset < s.insertAt {
+			offset = s.insertAt
+		}
+	}
+	return s.fieldOffset + offset + 1
+}
+
+// typeColumn returns the column of the type reference.
+// The parser sets the position of a list type after its opening bracket.
+func (s *gqlSignature) typeColumn(t *ast.Type) int {
+	if t.Elem != nil {
+		return s.column(t.Position) - 1
+	}
+	return s.column(t.Position)
+}
+
+func (s *gqlSignature) errorAt(column int, message string) error {
+	return &signatureError{Signature: s.Text, Column: column, Message: message}
+}
+
+// FieldName returns the name of the field or an empty string
+// if only the extended type is set in the signature.
+func (s *gqlSignature) FieldName() string {
+	if s.Field == nil {
+		return ""
+	}
+	return s.Field.Name
+}
+
+// ReturnType returns the type written in the signature or an empty string.
+func (s *gqlSignature) ReturnType() string {
+	if s.Field == nil || !s.HasType {
+		return ""
+	}
+	return s.Field.Type.String()
+}
+
+// Directive returns the directives of the field definition rendered as GraphQL.
+func (s *gqlSignature) Directive() string {
+	if s.Field == nil {
+		return ""
+	}
+	return renderDirectives(s.Field.Directives)
+}
+
+// apply checks the arguments and the return type from the signature against
+// the ones generated from the SQL query, and makes the query use the declared ones.
+func (s *gqlSignature) apply(q *Query) error {
+	if s.Field == nil {
+		return nil
+	}
+	if err := s.applyArguments(q); err != nil {
+		return err
+	}
+	return s.applyReturnType(q)
+}
+
+func (s *gqlSignature) applyArguments(q *Query) error {
+	declared := s.Field.Arguments
+	if len(declared) == 0 {
+		return nil
+	}
+	generated := q.Arg.Pairs()
+	if len(generated) == 0 {
+		return s.errorAt(s.column(declared[0].Position), "the query does not have parameters, but the signature has arguments")
+	}
+
+	if q.Arg.EmitStruct() {
+		if len(declared) != 1 {
+			return s.errorAt(
+				s.column(declared[1].Position),
+				fmt.Sprintf("the parameters of the query are passed as one input argument of type %s", generated[0].Type),
+			)
+		}
+		arg := declared[0]
+		if arg.Type.NamedType == "" || !arg.Type.NonNull {
+			return s.errorAt(
+				s.column(arg.Position),
+				fmt.Sprintf("argument %s should be a required input type like %s", arg.Name, generated[0].Type),
+			)
+		}
+		q.Arg.Name = arg.Name
+		q.Arg.Struct.Name = arg.Type.NamedType
+		q.Arg.Declared = []Argument{declaredArgument(arg)}
+		return nil
+	}
+
+	args := make([]Argument, 0, len(declared))
+	for _, arg := range declared {
+		var gen *Argument
+		for i := range generated {
+			if generated[i].Name == arg.Name {
+				gen = &generated[i]
+				break
+			}
+		}
+		if gen == nil {
+			return s.errorAt(
+				s.column(arg.Position),
+				fmt.Sprintf("argument %s does not match any parameter of the query", arg.Name),
+			)
+		}
+		if err := checkTypeCompatibility(gen.Type, arg.Type, true); err != nil {
+			return s.errorAt(s.column(arg.Position), fmt.Sprintf("argument %s: %s", arg.Name, err))
+		}
+		args = append(args, declaredArgument(arg))
+	}
+	for _, gen := range generated {
+		if declared.ForName(gen.Name) == nil {
+			return s.errorAt(
+				s.column(s.Field.Position),
+				fmt.Sprintf("parameter %s of the query is missing in the arguments", gen.Name),
+			)
+		}
+	}
+	q.Arg.Declared = args
+	return nil
+}
+
+func (s *gqlSignature) applyReturnType(q *Query) error {
+	if !s.HasType {
+		return nil
+	}
+	declared := s.Field.Type
+	generated := q.FieldType()
+	if generated == "" {
+		return nil
+	}
+	if q.Ret.IsStruct() && declared.Elem == nil && declared.NamedType == q.Ret.Struct.Name &&
+		baseType(generated) != declared.NamedType {
+		// the signature only names the returned row
+		return nil
+	}
+	if err := checkTypeCompatibility(generated, declared, false); err != nil {
+		return s.errorAt(s.typeColumn(declared), "return type: "+err.Error())
+	}
+	q.DeclaredType = declared.String()
+	return nil
+}
+
+// checkDirectives checks that the directives of the signature
+// are not repeated by the directives set in the options.
+func (s *gqlSignature) checkDirectives(configured string) error {
+	if s.Field == nil {
+		return nil
+	}
+	names := directiveNames(configured)
+	for _, d := range s.Field.Directives {
+		if slices.Contains(names, d.Name) {
+			// the position of a directive points at its name after "@"
+			return s.errorAt(
+				s.column(d.Position)-1,
+				fmt.Sprintf("directive @%s is also added to the field in the options", d.Name),
+			)
+		}
+	}
+	return nil
+}
+
+// directiveNames returns the names of the directives in the rendered directives, e.g. @goField(name: "id").
+func directiveNames(directives string) []string {
+	parts := strings.Split(directives, "@")
+	names := make([]string, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if name := gqlName.FindString(part); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// checkTypeCompatibility checks that the declared type can be used instead of the generated one.
+// A declared input can be stricter than the generated one, a declared output can be looser.
+func checkTypeCompatibility(generated string, declared *ast.Type, input bool) error {
+	genType := parseGqlType(generated)
+	for t, d := genType, declared; t != nil || d != nil; t, d = t.Elem, d.Elem {
+		if t == nil || d == nil || t.NamedType != d.NamedType {
+			return fmt.Errorf("declared type %s does not match the generated type %s", declared.String(), generated)
+		}
+		if input && t.NonNull && !d.NonNull {
+			return fmt.Errorf("declared type %s should be non-null like %s", declared.String(), generated)
+		}
+		if !input && !t.NonNull && d.NonNull {
+			return fmt.Errorf("declared type %s should be nullable like %s", declared.String(), generated)
+		}
+	}
+	return nil
+}
+
+func declaredArgument(arg *ast.ArgumentDefinition) Argument {
+	a := Argument{
+		Name:      arg.Name,
+		Type:      arg.Type.String(),
+		Directive: renderDirectives(arg.Directives),
+	}
+	if arg.DefaultValue != nil {
+		a.Default = arg.DefaultValue.String()
+	}
+	return a
+}
+
+func renderDirectives(directives ast.DirectiveList) string {
+	res := make([]string, 0, len(directives))
+	for _, d := range directives {
+		dn := "@" + d.Name
+		if len(d.Arguments) > 0 {
+			args := make([]string, 0, len(d.Arguments))
+			for _, arg := range d.Arguments {
+				args = append(args, arg.Name+": "+arg.Value.String())
+			}
+			dn += "(" + strings.Join(args, ", ") + ")"
+		}
+		res = append(res, dn)
+	}
+	return strings.Join(res, " ")
+}
+
+// parseGqlType parses a type reference generated by the plugin, e.g. [Author!]!
+func parseGqlType(t string) *ast.Type {
+	res := &ast.Type{}
+	if strings.HasSuffix(t, "!") {
+		res.NonNull = true
+		t = strings.TrimSuffix(t, "!")
+	}
+	if strings.HasPrefix(t, "[") && strings.HasSuffix(t, "]") {
+		res.Elem = parseGqlType(t[1 : len(t)-1])
+	} else {
+		res.NamedType = t
+	}
+	return res
+}
+
+// baseType strips the list and non-null modifiers from a GraphQL type.
+func baseType(t string) string {
+	return strings.Trim(t, "[]!")
+}
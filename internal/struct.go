@@ -15,6 +15,10 @@ type Struct struct {
 	Fields    []Field
 	Comment   string
 	ModelPath string
+	// Implements is the interface of the type, e.g. Node
+	Implements string
+	// Directive is the directive of the type, e.g. the federation key
+	Directive string
 }
 
 func StructName(name string, options *opts.Options) string {
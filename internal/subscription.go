@@ -0,0 +1,46 @@
+package golang
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+)
+
+// parseNotify returns the channel of the gql-notify comment, e.g.
+//
+//	-- gql-notify: author_updated
+//
+// The subscription field re-runs the query for the key sent to the channel.
+func parseNotify(comments []string) (string, []string) {
+	for i, comment := range comments {
+		text, ok := strings.CutPrefix(strings.TrimSpace(comment), "gql-notify:")
+		if !ok {
+			continue
+		}
+		comments = append(comments[:i], comments[i+1:]...)
+		return strings.TrimSpace(text), comments
+	}
+	return "", comments
+}
+
+// checkNotify checks that the notified query is the :one or :many query of the Subscription field
+// taking only the key sent to the channel.
+func checkNotify(q Query, sig *gqlSignature) error {
+	if q.Notify == "" {
+		return nil
+	}
+	if q.ExtendedType != "Subscription" {
+		return errors.New("gql-notify requires the Subscription field")
+	}
+	if q.Cmd != metadata.CmdOne && q.Cmd != metadata.CmdMany {
+		return errors.New("gql-notify requires the :one or :many query")
+	}
+	if q.Arg.isEmpty() || q.Arg.IsStruct() || len(q.Hidden) > 0 || q.Paginated {
+		return errors.New("gql-notify requires the query taking only the notified key")
+	}
+	if sig.Field != nil && len(sig.Field.Arguments) > 0 {
+		return errors.New("the subscription field has no arguments, the key is taken from the notification")
+	}
+	return nil
+}
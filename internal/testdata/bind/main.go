@@ -0,0 +1,59 @@
+// The bind command binds the schema generated into the directory to the generated Go code by gqlgen
+// and prints the input fields bound to graphql.Omittable. It is a separate module, so the version of
+// golang.org/x/tools loading the packages for gqlgen does not constrain the Go version of the plugin.
+//
+//	go run . <dir>
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/99designs/gqlgen/codegen"
+	"github.com/99designs/gqlgen/codegen/config"
+)
+
+func main() {
+	if err := run(os.Args[1]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string) error {
+	schemas, err := filepath.Glob(filepath.Join(dir, "out", "*.graphql"))
+	if err != nil {
+		return err
+	}
+	cfg := config.DefaultConfig()
+	cfg.SchemaFilename = schemas
+	cfg.Exec = config.ExecConfig{Filename: filepath.Join(dir, "exec", "generated.go"), Package: "exec"}
+	cfg.Model = config.PackageConfig{}
+	cfg.Resolver = config.ResolverConfig{}
+	if err := config.CompleteConfig(cfg); err != nil {
+		return err
+	}
+	if err := cfg.Init(); err != nil {
+		return err
+	}
+	data, err := codegen.BuildData(cfg)
+	if err != nil {
+		return err
+	}
+
+	var omittable []string
+	for _, input := range data.Inputs {
+		for _, f := range input.Fields {
+			if f.TypeReference.IsOmittable {
+				omittable = append(omittable, input.Name+"."+f.Name)
+			}
+		}
+	}
+	sort.Strings(omittable)
+	for _, f := range omittable {
+		fmt.Println(f)
+	}
+	return nil
+}
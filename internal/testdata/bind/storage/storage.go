@@ -0,0 +1,53 @@
+// Package storage is the code sqlc-gen-go generates with sql_package: pgx/v5 for the authors table,
+// the generated schema and delegates are bound to it by gqlgen in the tests.
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+)
+
+type Author struct {
+	ID     pgtype.UUID
+	Name   pgtype.Text
+	Status Status
+}
+
+type DBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db DBTX
+}
+
+const updateAuthor = `update authors set name = $3 where id = $1 and status = $2 returning id, name, status`
+
+type UpdateAuthorParams struct {
+	ID     pgtype.UUID
+	Status Status
+	Name   pgtype.Text
+}
+
+func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Author, error) {
+	row := q.db.QueryRow(ctx, updateAuthor, arg.ID, arg.Status, arg.Name)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.Status)
+	return i, err
+}
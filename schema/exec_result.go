@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// ExecResult is the payload of the :execresult mutations with the number of the affected rows.
+// LastInsertID is nil if the driver does not report the last inserted id.
+type ExecResult struct {
+	RowsAffected int64
+	LastInsertID *string
+}
+
+// NewExecResult converts the result of the :execresult query to the GraphQL payload.
+// It accepts sql.Result of database/sql and pgconn.CommandTag of pgx.
+func NewExecResult(result any) (ExecResult, error) {
+	switch r := result.(type) {
+	case sql.Result:
+		rows, err := r.RowsAffected()
+		if err != nil {
+			return ExecResult{}, err
+		}
+		res := ExecResult{RowsAffected: rows}
+		// not every driver supports the last inserted id
+		if id, err := r.LastInsertId(); err == nil {
+			lastID := strconv.FormatInt(id, 10)
+			res.LastInsertID = &lastID
+		}
+		return res, nil
+	case interface{ RowsAffected() int64 }:
+		return ExecResult{RowsAffected: r.RowsAffected()}, nil
+	}
+	return ExecResult{}, fmt.Errorf("unsupported result of the query: %T", result)
+}
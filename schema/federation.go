@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Entity is bound to the _Entity union of Apollo Federation, it is implemented by the models of the entities.
+type Entity interface{}
+
+// Service is bound to the _Service type of Apollo Federation, SDL is the schema of the subgraph.
+type Service struct {
+	SDL string `json:"sdl"`
+}
+
+// EntityKey reads the key field of the entity representation passed to the _entities query.
+func EntityKey[T any](representation map[string]any, field string) (T, error) {
+	var res T
+	value, ok := representation[field]
+	if !ok {
+		return res, fmt.Errorf("the representation of %v has no %s field", representation["__typename"], field)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return res, fmt.Errorf("invalid %s field of the representation: %w", field, err)
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return res, fmt.Errorf("invalid %s field of the representation: %w", field, err)
+	}
+	return res, nil
+}
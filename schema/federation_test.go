@@ -0,0 +1,52 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityKey(t *testing.T) {
+	t.Run(
+		"Read the key of the representation", func(t *testing.T) {
+			key := uuid.MustParse("9b2f4c3e-1a5d-4f0e-8c7b-2d6e8f1a3b4c")
+			representation := map[string]any{"__typename": "Author", "id": key.String()}
+
+			res, err := schema.EntityKey[uuid.UUID](representation, "id")
+
+			t.Log("Given the representation of the author with the UUID key")
+			t.Log("When the key is read")
+			t.Log("	Then the key should be the same")
+			require.NoError(t, err)
+			require.Equal(t, key, res)
+		},
+	)
+
+	t.Run(
+		"Keep the 64-bit integer keys", func(t *testing.T) {
+			representation := map[string]any{"__typename": "Post", "id": json.Number("9007199254740993")}
+
+			res, err := schema.EntityKey[int64](representation, "id")
+
+			t.Log("Given the representation of the post with the bigint key")
+			t.Log("When the key is read")
+			t.Log("	Then the key should not be rounded")
+			require.NoError(t, err)
+			require.Equal(t, int64(9007199254740993), res)
+		},
+	)
+
+	t.Run(
+		"Fail on the missing key", func(t *testing.T) {
+			_, err := schema.EntityKey[int32](map[string]any{"__typename": "Post"}, "id")
+
+			t.Log("Given the representation without the key field")
+			t.Log("When the key is read")
+			t.Log("	Then an error should be returned")
+			require.EqualError(t, err, "the representation of Post has no id field")
+		},
+	)
+}
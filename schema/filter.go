@@ -0,0 +1,269 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// FilterPlaceholder marks the place of the condition chosen by the client in the query with the gql-filter comment:
+//
+//	SELECT * FROM authors WHERE /* filter */ TRUE LIMIT $1 OFFSET $2;
+//
+// The query returns all the rows as is, and RewriteQuery replaces the placeholder with the condition.
+const FilterPlaceholder = "/* filter */ TRUE"
+
+// FilterColumn is the column of a field of the filter input.
+// Table is the table of the column or its alias in the query, so the columns of the joined tables are not ambiguous.
+// Values maps the GraphQL values of the enum column to the values stored in the database.
+type FilterColumn struct {
+	Table  string
+	Name   string
+	Values map[string]string
+}
+
+// FilterClause builds the SQL condition from the filter input generated from the gql-filter comment of the query:
+//
+//	clause, args, err := schema.FilterClause(filter, map[string]schema.FilterColumn{"createdAt": {Table: "authors", Name: "created_at"}}, 2)
+//
+// The columns map the fields of the input to the columns, only these columns get into the condition
+// as the quoted identifiers, e.g. "authors"."created_at".
+// The values are passed as the parameters numbered after the offset, so it is safe to put the condition into the query.
+// TRUE is returned if the filter is empty.
+func FilterClause(filter map[string]any, columns map[string]FilterColumn, offset int) (string, []any, error) {
+	b := filterBuilder{columns: columns, offset: offset}
+	clause, err := b.filter(filter)
+	if err != nil {
+		return "", nil, err
+	}
+	if clause == "" {
+		clause = "TRUE"
+	}
+	return clause, b.args, nil
+}
+
+type filterKey struct{}
+
+type contextFilter struct {
+	filter  map[string]any
+	columns map[string]FilterColumn
+}
+
+// WithFilter puts the filter chosen by the client into the context of the query,
+// RewriteQuery replaces FilterPlaceholder of the query with its condition.
+// The filter is checked here, so the invalid one fails the resolver before the query.
+func WithFilter(ctx context.Context, filter map[string]any, columns map[string]FilterColumn) (context.Context, error) {
+	if _, _, err := FilterClause(filter, columns, 0); err != nil {
+		return ctx, err
+	}
+	if len(filter) == 0 {
+		return ctx, nil
+	}
+	return context.WithValue(ctx, filterKey{}, contextFilter{filter: filter, columns: columns}), nil
+}
+
+// withFilter replaces FilterPlaceholder in the query with the condition of the filter of the context
+// and appends its parameters to the arguments of the query.
+func withFilter(ctx context.Context, query string, args []any) (string, []any) {
+	f, ok := ctx.Value(filterKey{}).(contextFilter)
+	if !ok || !strings.Contains(query, FilterPlaceholder) {
+		return query, args
+	}
+	// the filter is checked by WithFilter, so the condition is built without an error
+	clause, filterArgs, _ := FilterClause(f.filter, f.columns, len(args))
+	query = strings.Replace(query, FilterPlaceholder, "("+clause+")", 1)
+	return query, append(slices.Clip(args), filterArgs...)
+}
+
+type filterBuilder struct {
+	columns map[string]FilterColumn
+	offset  int
+	args    []any
+}
+
+func (b *filterBuilder) param(value any) string {
+	b.args = append(b.args, value)
+	return "$" + strconv.Itoa(b.offset+len(b.args))
+}
+
+// filter joins the conditions of the fields of the filter and of the and/or combinators with AND.
+func (b *filterBuilder) filter(filter map[string]any) (string, error) {
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var parts []string
+	for _, k := range keys {
+		value := deref(filter[k])
+		if value == nil {
+			continue
+		}
+		var part string
+		var err error
+		switch k {
+		case "and", "or":
+			part, err = b.combine(value, strings.ToUpper(k))
+		default:
+			column, ok := b.columns[k]
+			if !ok {
+				return "", fmt.Errorf("filtering by %s is not allowed", k)
+			}
+			operators, ok := value.(map[string]any)
+			if !ok {
+				return "", fmt.Errorf("invalid filter of %s: %T", k, value)
+			}
+			part, err = b.column(column, operators)
+		}
+		if err != nil {
+			return "", err
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+// combine joins the conditions of the list of the filters with AND or OR.
+func (b *filterBuilder) combine(value any, operator string) (string, error) {
+	list := reflect.ValueOf(value)
+	if list.Kind() != reflect.Slice {
+		return "", fmt.Errorf("invalid %s filter: %T", strings.ToLower(operator), value)
+	}
+	var parts []string
+	for i := 0; i < list.Len(); i++ {
+		filter, ok := deref(list.Index(i).Interface()).(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("invalid %s filter: %T", strings.ToLower(operator), list.Index(i).Interface())
+		}
+		part, err := b.filter(filter)
+		if err != nil {
+			return "", err
+		}
+		if part == "" {
+			part = "TRUE"
+		}
+		parts = append(parts, "("+part+")")
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+	return "(" + strings.Join(parts, " "+operator+" ") + ")", nil
+}
+
+// column builds the conditions of the operators of the column joined with AND.
+func (b *filterBuilder) column(c FilterColumn, operators map[string]any) (string, error) {
+	column := c.ident()
+	keys := make([]string, 0, len(operators))
+	for k := range operators {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var parts []string
+	for _, op := range keys {
+		value := deref(operators[op])
+		if value == nil {
+			continue
+		}
+		if op != "isNull" {
+			var err error
+			if value, err = c.dbValue(value); err != nil {
+				return "", err
+			}
+		}
+		switch op {
+		case "eq":
+			parts = append(parts, column+" = "+b.param(value))
+		case "gt":
+			parts = append(parts, column+" > "+b.param(value))
+		case "lt":
+			parts = append(parts, column+" < "+b.param(value))
+		case "contains":
+			parts = append(parts, "strpos("+column+", "+b.param(value)+") > 0")
+		case "in":
+			list := reflect.ValueOf(value)
+			if list.Kind() != reflect.Slice {
+				return "", fmt.Errorf("invalid in filter of %s: %T", c.Name, value)
+			}
+			if list.Len() == 0 {
+				parts = append(parts, "FALSE")
+				continue
+			}
+			params := make([]string, 0, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				params = append(params, b.param(list.Index(i).Interface()))
+			}
+			parts = append(parts, column+" IN ("+strings.Join(params, ", ")+")")
+		case "isNull":
+			isNull, ok := value.(bool)
+			if !ok {
+				return "", fmt.Errorf("invalid isNull filter of %s: %T", c.Name, value)
+			}
+			if isNull {
+				parts = append(parts, column+" IS NULL")
+			} else {
+				parts = append(parts, column+" IS NOT NULL")
+			}
+		default:
+			return "", fmt.Errorf("unknown filter operator %s", op)
+		}
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+// ident returns the quoted identifier of the column qualified by the table if it is set.
+func (c FilterColumn) ident() string {
+	if c.Table == "" {
+		return pgx.Identifier{c.Name}.Sanitize()
+	}
+	return pgx.Identifier{c.Table, c.Name}.Sanitize()
+}
+
+// dbValue turns the GraphQL values of the enum column into the values stored in the database,
+// the values of other columns are returned as is.
+func (c FilterColumn) dbValue(value any) (any, error) {
+	if c.Values == nil {
+		return value, nil
+	}
+	list := reflect.ValueOf(value)
+	if list.Kind() == reflect.Slice {
+		values := make([]any, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			v, err := c.dbValue(deref(list.Index(i).Interface()))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	name := fmt.Sprint(value)
+	v, ok := c.Values[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a value of %s", name, c.Name)
+	}
+	return v, nil
+}
+
+// deref returns the value of the pointer of the nullable field of the input, nil stays nil.
+func deref(value any) any {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
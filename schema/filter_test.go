@@ -0,0 +1,148 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/stretchr/testify/require"
+)
+
+var authorFilterColumns = map[string]schema.FilterColumn{
+	"name": {Name: "name"},
+	"status": {
+		Name:   "status",
+		Values: map[string]string{"active": "active", "banned": "banned", "inProgress": "in_progress"},
+	},
+	"createdAt": {Name: "created_at"},
+}
+
+func TestFilterClause(t *testing.T) {
+	t.Run(
+		"Build the condition of the operators", func(t *testing.T) {
+			name := "Bob"
+			filter := map[string]any{
+				"name":      map[string]any{"contains": &name, "isNull": false},
+				"status":    map[string]any{"in": []string{"active", "banned"}},
+				"createdAt": map[string]any{"gt": "2024-01-01", "lt": nil},
+			}
+
+			clause, args, err := schema.FilterClause(filter, authorFilterColumns, 2)
+
+			t.Log("Given the filter of the several columns")
+			t.Log("When the condition is built after the 2 arguments of the query")
+			t.Log("	Then the conditions of the columns should be joined with AND")
+			t.Log("	And the values should be passed as the parameters starting from $3")
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				`"created_at" > $3 AND strpos("name", $4) > 0 AND "name" IS NOT NULL AND "status" IN ($5, $6)`,
+				clause,
+			)
+			require.Equal(t, []any{"2024-01-01", "Bob", "active", "banned"}, args)
+		},
+	)
+
+	t.Run(
+		"Combine the filters with and/or", func(t *testing.T) {
+			filter := map[string]any{
+				"or": []map[string]any{
+					{"name": map[string]any{"eq": "Bob"}},
+					{"status": map[string]any{"eq": "active"}, "name": map[string]any{"isNull": true}},
+				},
+				"and": []map[string]any{},
+			}
+
+			clause, args, err := schema.FilterClause(filter, authorFilterColumns, 0)
+
+			t.Log("Given the or combinator of the two filters and the empty and combinator")
+			t.Log("When the condition is built")
+			t.Log("	Then the filters should be joined with OR")
+			t.Log("	And the empty combinator should be skipped")
+			require.NoError(t, err)
+			require.Equal(t, `(("name" = $1) OR ("name" IS NULL AND "status" = $2))`, clause)
+			require.Equal(t, []any{"Bob", "active"}, args)
+		},
+	)
+
+	t.Run(
+		"Qualify the columns of the joined tables", func(t *testing.T) {
+			columns := map[string]schema.FilterColumn{
+				"authorName": {Table: "a", Name: "name"},
+				"user":       {Table: "p", Name: "user"},
+			}
+			filter := map[string]any{
+				"authorName": map[string]any{"eq": "Bob"},
+				"user":       map[string]any{"isNull": true},
+			}
+
+			clause, args, err := schema.FilterClause(filter, columns, 0)
+
+			t.Log("Given the columns of the aliased tables, one of them is the reserved word")
+			t.Log("When the condition is built")
+			t.Log("	Then the columns should be the quoted identifiers qualified by the aliases")
+			require.NoError(t, err)
+			require.Equal(t, `"a"."name" = $1 AND "p"."user" IS NULL`, clause)
+			require.Equal(t, []any{"Bob"}, args)
+		},
+	)
+
+	t.Run(
+		"Match all the rows with the empty filter", func(t *testing.T) {
+			clause, args, err := schema.FilterClause(nil, authorFilterColumns, 2)
+
+			t.Log("Given no filter")
+			t.Log("When the condition is built")
+			t.Log("	Then it should be TRUE")
+			require.NoError(t, err)
+			require.Equal(t, "TRUE", clause)
+			require.Empty(t, args)
+		},
+	)
+
+	t.Run(
+		"Compare the enum column with the values stored in the database", func(t *testing.T) {
+			inProgress := "inProgress"
+			filter := map[string]any{
+				"or": []map[string]any{
+					{"status": map[string]any{"eq": &inProgress}},
+					{"status": map[string]any{"in": []any{"inProgress", "banned"}}},
+				},
+			}
+
+			clause, args, err := schema.FilterClause(filter, authorFilterColumns, 0)
+
+			t.Log("Given the filter of the enum column by the GraphQL values")
+			t.Log("When the condition is built")
+			t.Log("	Then the parameters should be the values stored in the database")
+			require.NoError(t, err)
+			require.Equal(t, `(("status" = $1) OR ("status" IN ($2, $3)))`, clause)
+			require.Equal(t, []any{"in_progress", "in_progress", "banned"}, args)
+		},
+	)
+
+	t.Run(
+		"Fail on the unknown value of the enum column", func(t *testing.T) {
+			filter := map[string]any{"status": map[string]any{"eq": "deleted"}}
+
+			_, _, err := schema.FilterClause(filter, authorFilterColumns, 0)
+
+			t.Log("Given the filter of the enum column by the value the enum does not have")
+			t.Log("When the condition is built")
+			t.Log("	Then an error should be returned")
+			require.EqualError(t, err, "deleted is not a value of status")
+		},
+	)
+
+	t.Run(
+		"Fail on the column not allowed for filtering", func(t *testing.T) {
+			filter := map[string]any{"password": map[string]any{"eq": "secret"}}
+
+			_, _, err := schema.FilterClause(filter, authorFilterColumns, 0)
+
+			t.Log("Given the filter of the column missing in the allowed columns")
+			t.Log("When the condition is built")
+			t.Log("	Then an error should be returned")
+			require.EqualError(t, err, "filtering by password is not allowed")
+		},
+	)
+}
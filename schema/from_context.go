@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// ContextValueFunc returns the value stored in the request context by the key
+// set in the @fromContext directive, e.g. the id of the authenticated user.
+type ContextValueFunc func(ctx context.Context, key string) (any, error)
+
+type hiddenParamKey string
+
+// FromContext implements the @fromContext directive for gqlgen:
+//
+//	cfg.Directives.FromContext = schema.FromContext(func(ctx context.Context, key string) (any, error) {
+//		return auth.CurrentUserID(ctx)
+//	})
+//
+// The found value is passed to the resolver as the hidden parameter of the query.
+func FromContext(
+	value ContextValueFunc,
+) func(ctx context.Context, obj any, next graphql.Resolver, param string, key string) (any, error) {
+	return func(ctx context.Context, obj any, next graphql.Resolver, param string, key string) (any, error) {
+		v, err := value(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return next(context.WithValue(ctx, hiddenParamKey(param), v))
+	}
+}
+
+// HiddenParam returns the value of the hidden query parameter put into the context by the @fromContext directive.
+func HiddenParam[T any](ctx context.Context, param string) (T, error) {
+	var res T
+	v := ctx.Value(hiddenParamKey(param))
+	if v == nil {
+		return res, fmt.Errorf("hidden param %s is not found in the context", param)
+	}
+	res, ok := v.(T)
+	if !ok {
+		return res, fmt.Errorf("hidden param %s has type %T instead of %T", param, v, res)
+	}
+	return res, nil
+}
@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Node is bound to the Relay Node interface, it is implemented by the models of the tables with the id column.
+type Node interface{}
+
+// EncodeGlobalID turns the name of the node type and its primary key into the opaque global ID.
+func EncodeGlobalID(typ string, key any) (string, error) {
+	value, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the key of %s: %w", typ, err)
+	}
+	return base64.StdEncoding.EncodeToString([]byte(typ + ":" + string(value))), nil
+}
+
+// DecodeGlobalID returns the name of the node type and its encoded primary key, which is read by GlobalIDKey.
+func DecodeGlobalID(id string) (typ string, key string, err error) {
+	value, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid global id %q: %w", id, err)
+	}
+	typ, key, ok := strings.Cut(string(value), ":")
+	if !ok || typ == "" {
+		return "", "", fmt.Errorf("invalid global id %q", id)
+	}
+	return typ, key, nil
+}
+
+// GlobalIDKey reads the primary key returned by DecodeGlobalID.
+func GlobalIDKey[T any](key string) (T, error) {
+	var res T
+	if err := json.Unmarshal([]byte(key), &res); err != nil {
+		return res, fmt.Errorf("invalid key of the global id: %w", err)
+	}
+	return res, nil
+}
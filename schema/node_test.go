@@ -0,0 +1,59 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalID(t *testing.T) {
+	t.Run(
+		"Restore the type and the key", func(t *testing.T) {
+			key := uuid.MustParse("9b2f4c3e-1a5d-4f0e-8c7b-2d6e8f1a3b4c")
+
+			id, err := schema.EncodeGlobalID("Author", key)
+			require.NoError(t, err)
+			typ, encodedKey, err := schema.DecodeGlobalID(id)
+			require.NoError(t, err)
+			decoded, err := schema.GlobalIDKey[uuid.UUID](encodedKey)
+
+			t.Log("Given the global id of the author with the UUID key")
+			t.Log("When it is decoded")
+			t.Log("	Then the type name and the key should be the same")
+			require.NoError(t, err)
+			require.Equal(t, "Author", typ)
+			require.Equal(t, key, decoded)
+		},
+	)
+
+	t.Run(
+		"Keep the 64-bit integer keys", func(t *testing.T) {
+			id, err := schema.EncodeGlobalID("Post", int64(9007199254740993))
+			require.NoError(t, err)
+			_, encodedKey, err := schema.DecodeGlobalID(id)
+			require.NoError(t, err)
+			decoded, err := schema.GlobalIDKey[int64](encodedKey)
+
+			t.Log("Given the global id of the post with the bigint key")
+			t.Log("When it is decoded")
+			t.Log("	Then the key should not be rounded")
+			require.NoError(t, err)
+			require.Equal(t, int64(9007199254740993), decoded)
+		},
+	)
+
+	t.Run(
+		"Fail on the invalid id", func(t *testing.T) {
+			_, _, notBase64Err := schema.DecodeGlobalID("not base64!")
+			_, _, noTypeErr := schema.DecodeGlobalID("MTI=")
+
+			t.Log("Given the ids that are not base64 or have no type name")
+			t.Log("When they are decoded")
+			t.Log("	Then the errors should be returned")
+			require.Error(t, notBase64Err)
+			require.EqualError(t, noTypeErr, `invalid global id "MTI="`)
+		},
+	)
+}
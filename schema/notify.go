@@ -0,0 +1,123 @@
+package schema
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Notifier delivers the payloads of the notifications sent to the channel until ctx is done.
+type Notifier interface {
+	Listen(ctx context.Context, channel string) (<-chan string, error)
+}
+
+// PgxNotifier listens to the Postgres notifications with a connection of the pool taken for every subscription.
+type PgxNotifier struct {
+	Pool *pgxpool.Pool
+}
+
+func NewPgxNotifier(pool *pgxpool.Pool) *PgxNotifier {
+	return &PgxNotifier{Pool: pool}
+}
+
+func (n *PgxNotifier) Listen(ctx context.Context, channel string) (<-chan string, error) {
+	conn, err := n.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to listen to %s: %w", channel, err)
+	}
+
+	payloads := make(chan string)
+	go func() {
+		defer close(payloads)
+		defer func() {
+			// the broken connection is not returned to the pool
+			_, _ = conn.Exec(context.Background(), "UNLISTEN *")
+			conn.Release()
+		}()
+		for {
+			notification, err := conn.Conn().WaitForNotification(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case payloads <- notification.Payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return payloads, nil
+}
+
+// Subscribe listens to the channel and sends the result of the query for the key of every notification.
+// The notifications of the deleted rows and with the invalid keys are skipped,
+// and the channel is closed when ctx is done or the query fails.
+// The error of the query is sent to the client with graphql.AddError before the channel is closed.
+func Subscribe[K, T any](
+	ctx context.Context,
+	notifier Notifier,
+	channel string,
+	query func(ctx context.Context, key K) (T, error),
+) (<-chan T, error) {
+	payloads, err := notifier.Listen(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	results := make(chan T)
+	go func() {
+		defer close(results)
+		for payload := range payloads {
+			key, err := NotifiedKey[K](payload)
+			if err != nil {
+				continue
+			}
+			res, err := query(ctx, key)
+			if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				reportError(ctx, fmt.Errorf("subscription to %s: %w", channel, err))
+				return
+			}
+			select {
+			case results <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results, nil
+}
+
+// reportError adds the error to the response of the subscription,
+// it is dropped if ctx is not the context of a gqlgen operation.
+func reportError(ctx context.Context, err error) {
+	if !graphql.HasOperationContext(ctx) {
+		return
+	}
+	graphql.AddError(ctx, err)
+}
+
+// NotifiedKey reads the key from the payload of the notification.
+// The payload is the JSON value of the key or the text of the key, e.g. pg_notify('author_updated', NEW.id::text).
+func NotifiedKey[K any](payload string) (K, error) {
+	var key K
+	if err := json.Unmarshal([]byte(payload), &key); err == nil {
+		return key, nil
+	}
+	if err := json.Unmarshal([]byte(strconv.Quote(payload)), &key); err != nil {
+		return key, fmt.Errorf("invalid key in the notification %q: %w", payload, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,144 @@
+package schema_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNotifier struct {
+	channel  string
+	payloads chan string
+}
+
+func newFakeNotifier() *fakeNotifier {
+	return &fakeNotifier{payloads: make(chan string)}
+}
+
+func (n *fakeNotifier) Listen(ctx context.Context, channel string) (<-chan string, error) {
+	n.channel = channel
+	res := make(chan string)
+	go func() {
+		defer close(res)
+		for {
+			select {
+			case p, ok := <-n.payloads:
+				if !ok {
+					return
+				}
+				res <- p
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res, nil
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run(
+		"Send the row of the notified key", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			notifier := newFakeNotifier()
+			key := uuid.MustParse("9b2f4c3e-1a5d-4f0e-8c7b-2d6e8f1a3b4c")
+			var queried []uuid.UUID
+			query := func(ctx context.Context, id uuid.UUID) (string, error) {
+				queried = append(queried, id)
+				return "author " + id.String(), nil
+			}
+
+			results, err := schema.Subscribe(ctx, notifier, "author_updated", query)
+			require.NoError(t, err)
+			notifier.payloads <- key.String()
+			res := <-results
+
+			t.Log("Given the subscription to the author_updated channel")
+			t.Log("When the id of the author is notified")
+			t.Log("	Then the query should be called with the id")
+			t.Log("	And its result should be sent to the subscriber")
+			require.Equal(t, "author_updated", notifier.channel)
+			require.Equal(t, []uuid.UUID{key}, queried)
+			require.Equal(t, "author "+key.String(), res)
+		},
+	)
+
+	t.Run(
+		"Skip the deleted rows and the invalid keys", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			notifier := newFakeNotifier()
+			query := func(ctx context.Context, id int64) ([]int64, error) {
+				if id == 1 {
+					return nil, pgx.ErrNoRows
+				}
+				return []int64{id, id}, nil
+			}
+
+			results, err := schema.Subscribe(ctx, notifier, "posts", query)
+			require.NoError(t, err)
+			notifier.payloads <- "1"
+			notifier.payloads <- "not a number"
+			notifier.payloads <- "2"
+			res := <-results
+
+			t.Log("Given the notifications of the deleted row, of the invalid key and of the existing rows")
+			t.Log("When they are sent to the subscription")
+			t.Log("	Then only the existing rows should be sent to the subscriber")
+			require.Equal(t, []int64{2, 2}, res)
+		},
+	)
+
+	t.Run(
+		"Close the subscription on the failed query", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{})
+			ctx = graphql.WithResponseContext(ctx, graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			notifier := newFakeNotifier()
+			query := func(ctx context.Context, id int32) (int32, error) {
+				return 0, errors.New("connection lost")
+			}
+
+			results, err := schema.Subscribe(ctx, notifier, "authors", query)
+			require.NoError(t, err)
+			notifier.payloads <- "1"
+			_, ok := <-results
+
+			t.Log("Given the query failing with an error")
+			t.Log("When the key is notified")
+			t.Log("	Then the subscription should be closed")
+			require.False(t, ok)
+			t.Log("	And the error should be sent to the client")
+			errs := graphql.GetErrors(ctx)
+			require.Len(t, errs, 1)
+			require.Equal(t, "subscription to authors: connection lost", errs[0].Message)
+		},
+	)
+
+	t.Run(
+		"Close the subscription when the context is done", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			notifier := newFakeNotifier()
+			query := func(ctx context.Context, id int32) (int32, error) {
+				return id, nil
+			}
+
+			results, err := schema.Subscribe(ctx, notifier, "authors", query)
+			require.NoError(t, err)
+			cancel()
+			_, ok := <-results
+
+			t.Log("Given the subscription")
+			t.Log("When the client unsubscribes")
+			t.Log("	Then the subscription should be closed")
+			require.False(t, ok)
+		},
+	)
+}
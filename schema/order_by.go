@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+func (d SortDirection) IsValid() bool {
+	return d == SortDirectionAsc || d == SortDirectionDesc
+}
+
+func (d *SortDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("sort direction must be a string")
+	}
+	*d = SortDirection(str)
+	if !d.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (d SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(d)))
+}
+
+// OrderBy is the sort key of the list chosen by the client.
+// Field is the name of the column generated from the gql-sort comment of the query.
+type OrderBy struct {
+	Field     string
+	Direction SortDirection
+}
+
+// OrderByClause builds the ORDER BY clause of the SQL query from the sort keys chosen by the client:
+//
+//	clause, err := schema.OrderByClause(arg.OrderBy, "name", "created_at")
+//
+// Only the allowed columns and the known directions get into the clause, so it is safe to put it into the query.
+// The empty string is returned if there are no sort keys.
+func OrderByClause(orderBy []OrderBy, allowed ...string) (string, error) {
+	if len(orderBy) == 0 {
+		return "", nil
+	}
+	keys := make([]string, 0, len(orderBy))
+	for _, o := range orderBy {
+		i := slices.Index(allowed, o.Field)
+		if i < 0 {
+			return "", fmt.Errorf("sorting by %s is not allowed", o.Field)
+		}
+		direction := o.Direction
+		if direction == "" {
+			direction = SortDirectionAsc
+		}
+		if !direction.IsValid() {
+			return "", fmt.Errorf("%s is not a valid SortDirection", direction)
+		}
+		keys = append(keys, allowed[i]+" "+string(direction))
+	}
+	return "ORDER BY " + strings.Join(keys, ", "), nil
+}
+
+// OrderByPlaceholder marks the place of the sort keys chosen by the client in the query with the gql-sort comment.
+// It is put before the default sort keys, which order the rows if the client chose nothing and break the ties:
+//
+//	SELECT * FROM authors ORDER BY /* order by */ id
+const OrderByPlaceholder = "/* order by */"
+
+type orderByKey struct{}
+
+// WithOrderBy puts the sort keys chosen by the client into the context of the query,
+// RewriteQuery puts them in place of OrderByPlaceholder of the query.
+// The keys are checked here, so the column that is not allowed fails the resolver before the query.
+func WithOrderBy(ctx context.Context, orderBy []OrderBy, allowed ...string) (context.Context, error) {
+	clause, err := OrderByClause(orderBy, allowed...)
+	if err != nil || clause == "" {
+		return ctx, err
+	}
+	return context.WithValue(ctx, orderByKey{}, strings.TrimPrefix(clause, "ORDER BY ")+","), nil
+}
+
+// withOrderBy puts the sort keys of the context in place of OrderByPlaceholder in the query.
+func withOrderBy(ctx context.Context, query string) string {
+	keys, ok := ctx.Value(orderByKey{}).(string)
+	if !ok {
+		return query
+	}
+	return strings.Replace(query, OrderByPlaceholder, keys, 1)
+}
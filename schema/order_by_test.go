@@ -0,0 +1,65 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderByClause(t *testing.T) {
+	t.Run(
+		"Build the clause of the allowed columns", func(t *testing.T) {
+			orderBy := []schema.OrderBy{
+				{Field: "created_at", Direction: schema.SortDirectionDesc},
+				{Field: "name"},
+			}
+
+			clause, err := schema.OrderByClause(orderBy, "name", "created_at")
+
+			t.Log("Given the sort keys of the allowed columns")
+			t.Log("When the ORDER BY clause is built")
+			t.Log("	Then the clause should list the columns in the same order")
+			require.NoError(t, err)
+			require.Equal(t, "ORDER BY created_at DESC, name ASC", clause)
+		},
+	)
+
+	t.Run(
+		"Build nothing without the sort keys", func(t *testing.T) {
+			clause, err := schema.OrderByClause(nil, "name")
+
+			t.Log("Given no sort keys")
+			t.Log("When the ORDER BY clause is built")
+			t.Log("	Then the clause should be empty")
+			require.NoError(t, err)
+			require.Empty(t, clause)
+		},
+	)
+
+	t.Run(
+		"Fail on the column that is not allowed", func(t *testing.T) {
+			orderBy := []schema.OrderBy{{Field: "name; drop table authors", Direction: schema.SortDirectionAsc}}
+
+			_, err := schema.OrderByClause(orderBy, "name")
+
+			t.Log("Given the sort key of the column that is not allowed")
+			t.Log("When the ORDER BY clause is built")
+			t.Log("	Then an error should be returned")
+			require.EqualError(t, err, "sorting by name; drop table authors is not allowed")
+		},
+	)
+
+	t.Run(
+		"Fail on the unknown direction", func(t *testing.T) {
+			orderBy := []schema.OrderBy{{Field: "name", Direction: "DESC; drop table authors"}}
+
+			_, err := schema.OrderByClause(orderBy, "name")
+
+			t.Log("Given the sort key with the unknown direction")
+			t.Log("When the ORDER BY clause is built")
+			t.Log("	Then an error should be returned")
+			require.EqualError(t, err, "DESC; drop table authors is not a valid SortDirection")
+		},
+	)
+}
@@ -1,8 +1,128 @@
 package schema
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
 type PageInfo struct {
 	HasNextPage     bool
 	HasPreviousPage bool
 	StartCursor     string
 	EndCursor       string
 }
+
+// PageRequest holds the Relay pagination arguments of the connection field.
+// The page is requested forward by first/after or backward by last/before.
+type PageRequest struct {
+	First  int
+	After  string
+	Last   int
+	Before string
+}
+
+// Backward reports whether the page is requested by the last or before arguments.
+func (r PageRequest) Backward() bool {
+	return r.Last > 0 || r.Before != ""
+}
+
+// Validate checks that the page is requested in one direction by a non-negative number of rows.
+func (r PageRequest) Validate() error {
+	if r.First < 0 {
+		return fmt.Errorf("first must be non-negative, got %d", r.First)
+	}
+	if r.Last < 0 {
+		return fmt.Errorf("last must be non-negative, got %d", r.Last)
+	}
+	if (r.First > 0 || r.After != "") && r.Backward() {
+		return fmt.Errorf("the page can not be requested by first/after and last/before at the same time")
+	}
+	return nil
+}
+
+// Limit returns the number of the requested rows.
+func (r PageRequest) Limit() int {
+	if r.Backward() {
+		return r.Last
+	}
+	return r.First
+}
+
+// Cursor returns the cursor the page starts from (after) or ends at (before).
+func (r PageRequest) Cursor() string {
+	if r.Backward() {
+		return r.Before
+	}
+	return r.After
+}
+
+// EncodeCursor turns the values of the ordering columns of the row into the opaque cursor.
+func EncodeCursor[T any](position T) (string, error) {
+	value, err := json.Marshal(position)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal a cursor: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(value), nil
+}
+
+// DecodeCursor restores the values of the ordering columns encoded by EncodeCursor.
+func DecodeCursor[T any](cursor string) (T, error) {
+	var position T
+	value, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return position, fmt.Errorf("failed to decode a cursor: %w", err)
+	}
+	if err := json.Unmarshal(value, &position); err != nil {
+		return position, fmt.Errorf("failed to unmarshal a cursor: %w", err)
+	}
+	return position, nil
+}
+
+// NewPageInfo cuts the page out of the rows fetched with the limit increased by one,
+// so the extra row tells that there is one more page in the direction of the pagination.
+// The rows of the backward page are expected in the reversed order and are returned in the direct one.
+//
+// The page in the opposite direction is told by hasRowsBehind, e.g. the result of the query probing
+// one row up to the after cursor (or from the before cursor for the backward pagination).
+// The page requested without a cursor has nothing behind it.
+//
+// The request is checked by PageRequest.Validate before the rows are cut.
+func NewPageInfo[T any](
+	rows []T,
+	req PageRequest,
+	hasRowsBehind bool,
+	cursor func(row T) (string, error),
+) ([]T, PageInfo, error) {
+	var info PageInfo
+	if err := req.Validate(); err != nil {
+		return nil, info, err
+	}
+	hasMore := len(rows) > req.Limit()
+	if hasMore {
+		rows = rows[:req.Limit()]
+	}
+	hasBehind := hasRowsBehind && req.Cursor() != ""
+	if req.Backward() {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+		info.HasPreviousPage = hasMore
+		info.HasNextPage = hasBehind
+	} else {
+		info.HasNextPage = hasMore
+		info.HasPreviousPage = hasBehind
+	}
+	if len(rows) == 0 {
+		return rows, info, nil
+	}
+
+	var err error
+	if info.StartCursor, err = cursor(rows[0]); err != nil {
+		return nil, info, err
+	}
+	if info.EndCursor, err = cursor(rows[len(rows)-1]); err != nil {
+		return nil, info, err
+	}
+	return rows, info, nil
+}
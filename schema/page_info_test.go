@@ -0,0 +1,234 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/require"
+)
+
+type authorCursor struct {
+	Name string
+	ID   int
+}
+
+func TestNewPageInfo(t *testing.T) {
+	// the rows are ordered by the name and the id
+	rows := []authorCursor{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 4}, {"e", 5}}
+	cursor := func(row authorCursor) (string, error) {
+		return schema.EncodeCursor(row)
+	}
+	after, err := cursor(rows[0])
+	require.NoError(t, err)
+	before, err := cursor(rows[4])
+	require.NoError(t, err)
+
+	t.Run(
+		"Page forward", func(t *testing.T) {
+			req := schema.PageRequest{First: 2, After: after}
+
+			page, info, err := schema.NewPageInfo(rows[1:4], req, true, cursor)
+
+			t.Log("Given three rows after the cursor are fetched for the page of two rows")
+			t.Log("And the row of the cursor is found behind the page")
+			t.Log("When the page info is built")
+			t.Log("	Then there should be no error")
+			require.NoError(t, err)
+			t.Log("	And the page should have both the previous and the next pages")
+			require.True(t, info.HasNextPage)
+			require.True(t, info.HasPreviousPage)
+			snaps.MatchSnapshot(t, page, info)
+		},
+	)
+
+	t.Run(
+		"Page forward from the cursor with nothing behind it", func(t *testing.T) {
+			req := schema.PageRequest{First: 2, After: after}
+
+			page, info, err := schema.NewPageInfo(rows[1:4], req, false, cursor)
+
+			t.Log("Given three rows after the cursor are fetched for the page of two rows")
+			t.Log("And no row is found up to the cursor, e.g. the row of the cursor is deleted")
+			t.Log("When the page info is built")
+			t.Log("	Then there should be no error")
+			require.NoError(t, err)
+			t.Log("	And the page should have only the next page")
+			require.True(t, info.HasNextPage)
+			require.False(t, info.HasPreviousPage)
+			require.Equal(t, rows[1:3], page)
+		},
+	)
+
+	t.Run(
+		"Page forward from the start", func(t *testing.T) {
+			req := schema.PageRequest{First: 5}
+
+			page, info, err := schema.NewPageInfo(rows, req, false, cursor)
+
+			t.Log("Given all the rows are fetched for the first page of five rows")
+			t.Log("When the page info is built")
+			t.Log("	Then there should be no error")
+			require.NoError(t, err)
+			t.Log("	And the page should have neither the previous nor the next pages")
+			require.False(t, info.HasNextPage)
+			require.False(t, info.HasPreviousPage)
+			snaps.MatchSnapshot(t, page, info)
+		},
+	)
+
+	t.Run(
+		"Page backward", func(t *testing.T) {
+			req := schema.PageRequest{Last: 2, Before: before}
+			fetched := []authorCursor{rows[3], rows[2], rows[1]}
+
+			page, info, err := schema.NewPageInfo(fetched, req, true, cursor)
+
+			t.Log("Given three rows before the cursor are fetched in the reversed order for the page of two rows")
+			t.Log("And the row of the cursor is found behind the page")
+			t.Log("When the page info is built")
+			t.Log("	Then there should be no error")
+			require.NoError(t, err)
+			t.Log("	And the page should have both the previous and the next pages")
+			require.True(t, info.HasNextPage)
+			require.True(t, info.HasPreviousPage)
+			t.Log("	And the rows should be returned in the direct order")
+			require.Equal(t, []authorCursor{rows[2], rows[3]}, page)
+			snaps.MatchSnapshot(t, page, info)
+		},
+	)
+
+	t.Run(
+		"Page backward from the cursor with nothing behind it", func(t *testing.T) {
+			req := schema.PageRequest{Last: 2, Before: before}
+			fetched := []authorCursor{rows[3], rows[2], rows[1]}
+
+			page, info, err := schema.NewPageInfo(fetched, req, false, cursor)
+
+			t.Log("Given three rows before the cursor are fetched in the reversed order for the page of two rows")
+			t.Log("And no row is found from the cursor, e.g. the row of the cursor is deleted")
+			t.Log("When the page info is built")
+			t.Log("	Then there should be no error")
+			require.NoError(t, err)
+			t.Log("	And the page should have only the previous page")
+			require.False(t, info.HasNextPage)
+			require.True(t, info.HasPreviousPage)
+			require.Equal(t, []authorCursor{rows[2], rows[3]}, page)
+		},
+	)
+
+	t.Run(
+		"Page backward to the start", func(t *testing.T) {
+			req := schema.PageRequest{Last: 10, Before: before}
+			fetched := []authorCursor{rows[3], rows[2], rows[1], rows[0]}
+
+			page, info, err := schema.NewPageInfo(fetched, req, true, cursor)
+
+			t.Log("Given all the rows before the cursor are fetched in the reversed order for the page of ten rows")
+			t.Log("When the page info is built")
+			t.Log("	Then there should be no error")
+			require.NoError(t, err)
+			t.Log("	And the page should have only the next page")
+			require.True(t, info.HasNextPage)
+			require.False(t, info.HasPreviousPage)
+			snaps.MatchSnapshot(t, page, info)
+		},
+	)
+
+	t.Run(
+		"Page forward by zero rows", func(t *testing.T) {
+			req := schema.PageRequest{First: 0, After: after}
+
+			page, info, err := schema.NewPageInfo(rows[1:2], req, true, cursor)
+
+			t.Log("Given one row after the cursor is fetched for the page of zero rows")
+			t.Log("When the page info is built")
+			t.Log("	Then there should be no error")
+			require.NoError(t, err)
+			t.Log("	And the page should be empty with both the previous and the next pages")
+			require.Empty(t, page)
+			require.True(t, info.HasNextPage)
+			require.True(t, info.HasPreviousPage)
+			require.Empty(t, info.StartCursor)
+			require.Empty(t, info.EndCursor)
+		},
+	)
+
+	t.Run(
+		"Page backward by zero rows", func(t *testing.T) {
+			req := schema.PageRequest{Last: 0, Before: before}
+
+			page, info, err := schema.NewPageInfo(rows[3:4], req, true, cursor)
+
+			t.Log("Given one row before the cursor is fetched for the page of zero rows")
+			t.Log("When the page info is built")
+			t.Log("	Then there should be no error")
+			require.NoError(t, err)
+			t.Log("	And the page should be empty with both the previous and the next pages")
+			require.Empty(t, page)
+			require.True(t, info.HasNextPage)
+			require.True(t, info.HasPreviousPage)
+		},
+	)
+
+	for _, tc := range []struct {
+		name string
+		req  schema.PageRequest
+		err  string
+	}{
+		{
+			name: "Fail on the negative first",
+			req:  schema.PageRequest{First: -1},
+			err:  "first must be non-negative, got -1",
+		},
+		{
+			name: "Fail on the negative last",
+			req:  schema.PageRequest{Last: -1, Before: before},
+			err:  "last must be non-negative, got -1",
+		},
+		{
+			name: "Fail on the page requested in both directions",
+			req:  schema.PageRequest{First: 2, Last: 2, Before: before},
+			err:  "the page can not be requested by first/after and last/before at the same time",
+		},
+	} {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				_, _, err := schema.NewPageInfo(rows, tc.req, false, cursor)
+
+				t.Log("Given the invalid page request")
+				t.Log("When the page info is built")
+				t.Log("	Then an error should be returned")
+				require.EqualError(t, err, tc.err)
+			},
+		)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	t.Run(
+		"Decode the encoded cursor", func(t *testing.T) {
+			cursor, err := schema.EncodeCursor(authorCursor{Name: "a", ID: 1})
+			require.NoError(t, err)
+
+			position, err := schema.DecodeCursor[authorCursor](cursor)
+
+			t.Log("Given the cursor encoded from the row values")
+			t.Log("When the cursor is decoded")
+			t.Log("	Then the same values should be returned")
+			require.NoError(t, err)
+			require.Equal(t, authorCursor{Name: "a", ID: 1}, position)
+		},
+	)
+
+	t.Run(
+		"Fail on the invalid cursor", func(t *testing.T) {
+			_, err := schema.DecodeCursor[authorCursor]("not a cursor")
+
+			t.Log("Given the cursor that is not base64 encoded JSON")
+			t.Log("When the cursor is decoded")
+			t.Log("	Then an error should be returned")
+			require.Error(t, err)
+		},
+	)
+}
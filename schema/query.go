@@ -0,0 +1,13 @@
+package schema
+
+import "context"
+
+// RewriteQuery applies the sort keys and the filter put into the context by WithOrderBy and WithFilter
+// to the query with their placeholders, the parameters of the filter are appended to the arguments.
+// The queries without the placeholders are returned as is.
+//
+// Call it in the wrapper of the DBTX the queries are created with, the generated resolvers come with one.
+func RewriteQuery(ctx context.Context, query string, args []any) (string, []any) {
+	query = withOrderBy(ctx, query)
+	return withFilter(ctx, query, args)
+}
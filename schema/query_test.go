@@ -0,0 +1,71 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteQuery(t *testing.T) {
+	ctx := context.Background()
+	query := "SELECT id, name FROM authors WHERE /* filter */ TRUE ORDER BY /* order by */ id LIMIT $1 OFFSET $2"
+
+	t.Run(
+		"Apply the sort keys and the filter of the context", func(t *testing.T) {
+			ctx, err := schema.WithOrderBy(
+				ctx,
+				[]schema.OrderBy{{Field: "name", Direction: schema.SortDirectionDesc}},
+				"name", "created_at",
+			)
+			require.NoError(t, err)
+			ctx, err = schema.WithFilter(ctx, map[string]any{"name": map[string]any{"eq": "Bob"}}, authorFilterColumns)
+			require.NoError(t, err)
+
+			res, args := schema.RewriteQuery(ctx, query, []any{10, 0})
+
+			t.Log("Given the sort keys and the filter chosen by the client in the context")
+			t.Log("When the query with the placeholders is rewritten")
+			t.Log("	Then the sort keys should be put before the default ones")
+			t.Log("	And the filter placeholder should be replaced with the condition")
+			t.Log("	And the value of the filter should be added after the arguments")
+			require.Equal(
+				t,
+				`SELECT id, name FROM authors WHERE ("name" = $3) ORDER BY name DESC, id LIMIT $1 OFFSET $2`,
+				res,
+			)
+			require.Equal(t, []any{10, 0, "Bob"}, args)
+		},
+	)
+
+	t.Run(
+		"Keep the query without the sort keys and the filter", func(t *testing.T) {
+			ctx, err := schema.WithOrderBy(ctx, nil, "name")
+			require.NoError(t, err)
+			ctx, err = schema.WithFilter(ctx, nil, authorFilterColumns)
+			require.NoError(t, err)
+
+			res, args := schema.RewriteQuery(ctx, query, []any{10, 0})
+
+			t.Log("Given the client chose neither the sort keys nor the filter")
+			t.Log("When the query is rewritten")
+			t.Log("	Then the query should be returned as is")
+			require.Equal(t, query, res)
+			require.Equal(t, []any{10, 0}, args)
+		},
+	)
+
+	t.Run(
+		"Fail on the sort key and the filter that are not allowed", func(t *testing.T) {
+			_, orderByErr := schema.WithOrderBy(ctx, []schema.OrderBy{{Field: "password"}}, "name")
+			_, filterErr := schema.WithFilter(ctx, map[string]any{"password": map[string]any{"eq": "secret"}}, authorFilterColumns)
+
+			t.Log("Given the sort key and the filter of the column that is not allowed")
+			t.Log("When they are put into the context")
+			t.Log("	Then the errors should be returned before the query")
+			require.EqualError(t, orderByErr, "sorting by password is not allowed")
+			require.EqualError(t, filterErr, "filtering by password is not allowed")
+		},
+	)
+}
@@ -0,0 +1,260 @@
+package schema
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// rangeBounds is the shape shared by the range adapters.
+// The adapters are declared separately to be bound to the GraphQL types by gqlgen.
+type rangeBounds[V any] struct {
+	Lower          *V
+	Upper          *V
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+// DateRange is the daterange value bound to the DateRange and DateRangeInput types.
+// The bounds are nil on the unbounded sides of the range, and Empty is true if the range contains no points.
+type DateRange struct {
+	Lower          *time.Time
+	Upper          *time.Time
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+// TimestampRange is the tsrange or tstzrange value bound to the TimestampRange and TimestampRangeInput types.
+type TimestampRange struct {
+	Lower          *time.Time
+	Upper          *time.Time
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+// NumericRange is the numrange value bound to the NumericRange and NumericRangeInput types.
+// The bounds are kept as strings to not lose the precision.
+type NumericRange struct {
+	Lower          *string
+	Upper          *string
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+// IntRange is the int4range value bound to the IntRange and IntRangeInput types.
+type IntRange struct {
+	Lower          *int32
+	Upper          *int32
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+// BigIntRange is the int8range value bound to the BigIntRange and BigIntRangeInput types.
+type BigIntRange struct {
+	Lower          *int64
+	Upper          *int64
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+// NewDateRange converts the daterange value of pgx, it is nil if the value is null.
+func NewDateRange(r pgtype.Range[pgtype.Date]) (*DateRange, error) {
+	b, err := fromRange(r, func(v pgtype.Date) (time.Time, error) {
+		return v.Time, nil
+	})
+	return (*DateRange)(b), err
+}
+
+// Date converts the range back to the daterange value of pgx, it is null if the range is nil.
+func (r *DateRange) Date() (pgtype.Range[pgtype.Date], error) {
+	return toRange((*rangeBounds[time.Time])(r), func(v time.Time) (pgtype.Date, error) {
+		return pgtype.Date{Time: v, Valid: true}, nil
+	})
+}
+
+// NewTimestampRange converts the tsrange value of pgx, it is nil if the value is null.
+func NewTimestampRange(r pgtype.Range[pgtype.Timestamp]) (*TimestampRange, error) {
+	b, err := fromRange(r, func(v pgtype.Timestamp) (time.Time, error) {
+		return v.Time, nil
+	})
+	return (*TimestampRange)(b), err
+}
+
+// Timestamp converts the range back to the tsrange value of pgx, it is null if the range is nil.
+func (r *TimestampRange) Timestamp() (pgtype.Range[pgtype.Timestamp], error) {
+	return toRange((*rangeBounds[time.Time])(r), func(v time.Time) (pgtype.Timestamp, error) {
+		return pgtype.Timestamp{Time: v, Valid: true}, nil
+	})
+}
+
+// NewTimestamptzRange converts the tstzrange value of pgx, it is nil if the value is null.
+func NewTimestamptzRange(r pgtype.Range[pgtype.Timestamptz]) (*TimestampRange, error) {
+	b, err := fromRange(r, func(v pgtype.Timestamptz) (time.Time, error) {
+		return v.Time, nil
+	})
+	return (*TimestampRange)(b), err
+}
+
+// Timestamptz converts the range back to the tstzrange value of pgx, it is null if the range is nil.
+func (r *TimestampRange) Timestamptz() (pgtype.Range[pgtype.Timestamptz], error) {
+	return toRange((*rangeBounds[time.Time])(r), func(v time.Time) (pgtype.Timestamptz, error) {
+		return pgtype.Timestamptz{Time: v, Valid: true}, nil
+	})
+}
+
+// NewNumericRange converts the numrange value of pgx, it is nil if the value is null.
+func NewNumericRange(r pgtype.Range[pgtype.Numeric]) (*NumericRange, error) {
+	b, err := fromRange(r, func(v pgtype.Numeric) (string, error) {
+		value, err := v.Value()
+		if err != nil {
+			return "", err
+		}
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("the bound of numrange is %T instead of a number", value)
+		}
+		return s, nil
+	})
+	return (*NumericRange)(b), err
+}
+
+// Numeric converts the range back to the numrange value of pgx, it is null if the range is nil.
+func (r *NumericRange) Numeric() (pgtype.Range[pgtype.Numeric], error) {
+	return toRange((*rangeBounds[string])(r), func(v string) (res pgtype.Numeric, err error) {
+		err = res.Scan(v)
+		return res, err
+	})
+}
+
+// NewInt4Range converts the int4range value of pgx, it is nil if the value is null.
+func NewInt4Range(r pgtype.Range[pgtype.Int4]) (*IntRange, error) {
+	b, err := fromRange(r, func(v pgtype.Int4) (int32, error) {
+		return v.Int32, nil
+	})
+	return (*IntRange)(b), err
+}
+
+// Int4 converts the range back to the int4range value of pgx, it is null if the range is nil.
+func (r *IntRange) Int4() (pgtype.Range[pgtype.Int4], error) {
+	return toRange((*rangeBounds[int32])(r), func(v int32) (pgtype.Int4, error) {
+		return pgtype.Int4{Int32: v, Valid: true}, nil
+	})
+}
+
+// NewInt8Range converts the int8range value of pgx, it is nil if the value is null.
+func NewInt8Range(r pgtype.Range[pgtype.Int8]) (*BigIntRange, error) {
+	b, err := fromRange(r, func(v pgtype.Int8) (int64, error) {
+		return v.Int64, nil
+	})
+	return (*BigIntRange)(b), err
+}
+
+// Int8 converts the range back to the int8range value of pgx, it is null if the range is nil.
+func (r *BigIntRange) Int8() (pgtype.Range[pgtype.Int8], error) {
+	return toRange((*rangeBounds[int64])(r), func(v int64) (pgtype.Int8, error) {
+		return pgtype.Int8{Int64: v, Valid: true}, nil
+	})
+}
+
+// Ranges converts the multirange value of pgx by the constructor of the adapter:
+//
+//	return schema.Ranges(obj.Slots, schema.NewTimestamptzRange)
+func Ranges[T, R any](m pgtype.Multirange[pgtype.Range[T]], convert func(pgtype.Range[T]) (*R, error)) ([]*R, error) {
+	if m == nil {
+		return nil, nil
+	}
+	res := make([]*R, 0, len(m))
+	for _, r := range m {
+		item, err := convert(r)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+// Multirange converts the adapters back to the multirange value of pgx:
+//
+//	obj.Slots, err = schema.Multirange(data, (*schema.TimestampRange).Timestamptz)
+func Multirange[R, T any](ranges []*R, convert func(*R) (pgtype.Range[T], error)) (pgtype.Multirange[pgtype.Range[T]], error) {
+	if ranges == nil {
+		return nil, nil
+	}
+	res := make(pgtype.Multirange[pgtype.Range[T]], 0, len(ranges))
+	for _, r := range ranges {
+		item, err := convert(r)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+func fromRange[T, V any](r pgtype.Range[T], bound func(T) (V, error)) (*rangeBounds[V], error) {
+	if !r.Valid {
+		return nil, nil
+	}
+	res := &rangeBounds[V]{}
+	if r.LowerType == pgtype.Empty || r.UpperType == pgtype.Empty {
+		res.Empty = true
+		return res, nil
+	}
+	if r.LowerType != pgtype.Unbounded {
+		v, err := bound(r.Lower)
+		if err != nil {
+			return nil, err
+		}
+		res.Lower = &v
+		res.LowerInclusive = r.LowerType == pgtype.Inclusive
+	}
+	if r.UpperType != pgtype.Unbounded {
+		v, err := bound(r.Upper)
+		if err != nil {
+			return nil, err
+		}
+		res.Upper = &v
+		res.UpperInclusive = r.UpperType == pgtype.Inclusive
+	}
+	return res, nil
+}
+
+func toRange[T, V any](r *rangeBounds[V], bound func(V) (T, error)) (res pgtype.Range[T], err error) {
+	if r == nil {
+		return res, nil
+	}
+	res.Valid = true
+	if r.Empty {
+		res.LowerType, res.UpperType = pgtype.Empty, pgtype.Empty
+		return res, nil
+	}
+	res.LowerType, res.UpperType = pgtype.Unbounded, pgtype.Unbounded
+	if r.Lower != nil {
+		if res.Lower, err = bound(*r.Lower); err != nil {
+			return res, err
+		}
+		res.LowerType = boundType(r.LowerInclusive)
+	}
+	if r.Upper != nil {
+		if res.Upper, err = bound(*r.Upper); err != nil {
+			return res, err
+		}
+		res.UpperType = boundType(r.UpperInclusive)
+	}
+	return res, nil
+}
+
+func boundType(inclusive bool) pgtype.BoundType {
+	if inclusive {
+		return pgtype.Inclusive
+	}
+	return pgtype.Exclusive
+}
@@ -0,0 +1,131 @@
+package schema_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange(t *testing.T) {
+	t.Run(
+		"Convert the bounded range", func(t *testing.T) {
+			start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+			end := start.Add(time.Hour)
+			r := pgtype.Range[pgtype.Timestamptz]{
+				Lower:     pgtype.Timestamptz{Time: start, Valid: true},
+				Upper:     pgtype.Timestamptz{Time: end, Valid: true},
+				LowerType: pgtype.Inclusive,
+				UpperType: pgtype.Exclusive,
+				Valid:     true,
+			}
+
+			res, err := schema.NewTimestamptzRange(r)
+
+			t.Log("Given the tstzrange value including the lower bound")
+			t.Log("When it is converted to the adapter")
+			t.Log("	Then the adapter should have both bounds")
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				&schema.TimestampRange{Lower: &start, Upper: &end, LowerInclusive: true},
+				res,
+			)
+			t.Log("	And the adapter should be converted back to the same value")
+			back, err := res.Timestamptz()
+			require.NoError(t, err)
+			require.Equal(t, r, back)
+		},
+	)
+
+	t.Run(
+		"Convert the unbounded and the empty ranges", func(t *testing.T) {
+			from := int64(10)
+			unbounded := &schema.BigIntRange{Lower: &from, LowerInclusive: true}
+			empty := &schema.BigIntRange{Empty: true}
+
+			r, err := unbounded.Int8()
+			require.NoError(t, err)
+			e, err := empty.Int8()
+			require.NoError(t, err)
+
+			t.Log("Given the range without the upper bound and the empty range")
+			t.Log("When they are converted to the int8range values")
+			t.Log("	Then the range should be unbounded above")
+			require.Equal(t, pgtype.Inclusive, r.LowerType)
+			require.Equal(t, int64(10), r.Lower.Int64)
+			require.Equal(t, pgtype.Unbounded, r.UpperType)
+			t.Log("	And the empty range should have the empty bounds")
+			require.Equal(t, pgtype.Empty, e.LowerType)
+			require.Equal(t, pgtype.Empty, e.UpperType)
+			require.True(t, e.Valid)
+		},
+	)
+
+	t.Run(
+		"Keep the null range", func(t *testing.T) {
+			res, err := schema.NewDateRange(pgtype.Range[pgtype.Date]{})
+			require.NoError(t, err)
+			var empty *schema.DateRange
+			back, err := empty.Date()
+			require.NoError(t, err)
+
+			t.Log("Given the null daterange value and the nil adapter")
+			t.Log("When they are converted")
+			t.Log("	Then the adapter should be nil and the value should be null")
+			require.Nil(t, res)
+			require.False(t, back.Valid)
+		},
+	)
+
+	t.Run(
+		"Keep the precision of the numeric bounds", func(t *testing.T) {
+			lower, upper := "0.1", "12345678901234567890.123"
+			r := &schema.NumericRange{Lower: &lower, Upper: &upper, LowerInclusive: true}
+
+			value, err := r.Numeric()
+			require.NoError(t, err)
+			res, err := schema.NewNumericRange(value)
+
+			t.Log("Given the numrange with the bounds not fitting float64")
+			t.Log("When it is converted to the numrange value and back")
+			t.Log("	Then the bounds should be the same")
+			require.NoError(t, err)
+			require.Equal(t, r, res)
+		},
+	)
+
+	t.Run(
+		"Convert the multirange", func(t *testing.T) {
+			from, to := int32(1), int32(5)
+			ranges := []*schema.IntRange{{Lower: &from, Upper: &to, LowerInclusive: true}, {Empty: true}}
+
+			m, err := schema.Multirange(ranges, (*schema.IntRange).Int4)
+			require.NoError(t, err)
+			res, err := schema.Ranges(m, schema.NewInt4Range)
+
+			t.Log("Given the list of the int4range adapters")
+			t.Log("When it is converted to the multirange value and back")
+			t.Log("	Then the list should be the same")
+			require.NoError(t, err)
+			require.Len(t, m, 2)
+			require.Equal(t, ranges, res)
+		},
+	)
+
+	t.Run(
+		"Fail on the invalid numeric bound", func(t *testing.T) {
+			lower := "ten"
+			r := &schema.NumericRange{Lower: &lower}
+
+			_, err := r.Numeric()
+
+			t.Log("Given the numrange with the bound that is not a number")
+			t.Log("When it is converted to the numrange value")
+			t.Log("	Then an error should be returned")
+			require.Error(t, err)
+		},
+	)
+}
@@ -0,0 +1,241 @@
+package schema
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// The marshalers of the scalars declared in common.graphql for the Go types of the sqlc code.
+
+func MarshalNullTime(t sql.NullTime) graphql.Marshaler {
+	if !t.Valid {
+		return graphql.Null
+	}
+	return graphql.MarshalTime(t.Time)
+}
+
+func UnmarshalNullTime(v any) (sql.NullTime, error) {
+	if v == nil {
+		return sql.NullTime{}, nil
+	}
+	t, err := graphql.UnmarshalTime(v)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
+
+func MarshalUUID(id uuid.UUID) graphql.Marshaler {
+	return graphql.MarshalString(id.String())
+}
+
+func UnmarshalUUID(v any) (uuid.UUID, error) {
+	s, ok := v.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("%T is not a UUID string", v)
+	}
+	return uuid.Parse(s)
+}
+
+func MarshalNullUUID(id uuid.NullUUID) graphql.Marshaler {
+	if !id.Valid {
+		return graphql.Null
+	}
+	return MarshalUUID(id.UUID)
+}
+
+func UnmarshalNullUUID(v any) (uuid.NullUUID, error) {
+	if v == nil {
+		return uuid.NullUUID{}, nil
+	}
+	id, err := UnmarshalUUID(v)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: id, Valid: true}, nil
+}
+
+// MarshalJSON writes the JSON document as is, so the client gets the object instead of the string.
+func MarshalJSON(doc json.RawMessage) graphql.Marshaler {
+	return graphql.WriterFunc(
+		func(w io.Writer) {
+			if len(doc) == 0 {
+				doc = json.RawMessage("null")
+			}
+			_, _ = w.Write(doc)
+		},
+	)
+}
+
+func UnmarshalJSON(v any) (json.RawMessage, error) {
+	return json.Marshal(v)
+}
+
+// MarshalNullRawMessage writes the nullable JSON column of database/sql, the SQL null is written as null.
+func MarshalNullRawMessage(doc pqtype.NullRawMessage) graphql.Marshaler {
+	if !doc.Valid {
+		return graphql.Null
+	}
+	return MarshalJSON(doc.RawMessage)
+}
+
+func UnmarshalNullRawMessage(v any) (pqtype.NullRawMessage, error) {
+	if v == nil {
+		return pqtype.NullRawMessage{}, nil
+	}
+	doc, err := UnmarshalJSON(v)
+	if err != nil {
+		return pqtype.NullRawMessage{}, err
+	}
+	return pqtype.NullRawMessage{RawMessage: doc, Valid: true}, nil
+}
+
+func MarshalJSONBytes(doc []byte) graphql.Marshaler {
+	return MarshalJSON(doc)
+}
+
+func UnmarshalJSONBytes(v any) ([]byte, error) {
+	return UnmarshalJSON(v)
+}
+
+// MarshalUnknown writes the value of the column the type of which is unknown to sqlc.
+func MarshalUnknown(v any) graphql.Marshaler {
+	return graphql.MarshalAny(v)
+}
+
+func UnmarshalUnknown(v any) (any, error) {
+	return v, nil
+}
+
+// MarshalInt64 writes the 64-bit integer as the JSON number with all its digits.
+func MarshalInt64(i int64) graphql.Marshaler {
+	return graphql.MarshalInt64(i)
+}
+
+// UnmarshalInt64 reads the 64-bit integer without rounding it through float64.
+func UnmarshalInt64(v any) (int64, error) {
+	switch v := v.(type) {
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case json.Number:
+		return strconv.ParseInt(string(v), 10, 64)
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not a 64-bit integer", v)
+		}
+		return int64(v), nil
+	}
+	return 0, fmt.Errorf("%T is not a 64-bit integer", v)
+}
+
+// UnmarshalInt16 reads the smallint, the values out of its range are rejected instead of being wrapped.
+func UnmarshalInt16(v any) (int16, error) {
+	i, err := graphql.UnmarshalInt(v)
+	if err != nil {
+		return 0, err
+	}
+	if i < math.MinInt16 || i > math.MaxInt16 {
+		return 0, fmt.Errorf("%d overflows the 16-bit integer", i)
+	}
+	return int16(i), nil
+}
+
+func MarshalNullInt64(i sql.NullInt64) graphql.Marshaler {
+	if !i.Valid {
+		return graphql.Null
+	}
+	return MarshalInt64(i.Int64)
+}
+
+func UnmarshalNullInt64(v any) (sql.NullInt64, error) {
+	if v == nil {
+		return sql.NullInt64{}, nil
+	}
+	i, err := UnmarshalInt64(v)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	return sql.NullInt64{Int64: i, Valid: true}, nil
+}
+
+// MarshalBigInt writes the 64-bit integer as the string, so the JavaScript clients do not round it.
+func MarshalBigInt(i int64) graphql.Marshaler {
+	return graphql.MarshalString(strconv.FormatInt(i, 10))
+}
+
+// UnmarshalBigInt reads the 64-bit integer sent as the string or as the number.
+func UnmarshalBigInt(v any) (int64, error) {
+	return UnmarshalInt64(v)
+}
+
+func MarshalNullBigInt(i sql.NullInt64) graphql.Marshaler {
+	if !i.Valid {
+		return graphql.Null
+	}
+	return MarshalBigInt(i.Int64)
+}
+
+func UnmarshalNullBigInt(v any) (sql.NullInt64, error) {
+	return UnmarshalNullInt64(v)
+}
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// MarshalDecimal writes the numeric value as the string to keep its precision.
+func MarshalDecimal(d string) graphql.Marshaler {
+	return graphql.MarshalString(d)
+}
+
+// UnmarshalDecimal reads the numeric value sent as the string or as the number.
+func UnmarshalDecimal(v any) (string, error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = string(v)
+	case int, int32, int64:
+		s = fmt.Sprint(v)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return "", fmt.Errorf("%T is not a decimal", v)
+	}
+	if !decimalPattern.MatchString(s) {
+		return "", fmt.Errorf("%q is not a decimal", s)
+	}
+	return s, nil
+}
+
+func MarshalNullDecimal(d sql.NullString) graphql.Marshaler {
+	if !d.Valid {
+		return graphql.Null
+	}
+	return MarshalDecimal(d.String)
+}
+
+func UnmarshalNullDecimal(v any) (sql.NullString, error) {
+	if v == nil {
+		return sql.NullString{}, nil
+	}
+	d, err := UnmarshalDecimal(v)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: d, Valid: true}, nil
+}
@@ -0,0 +1,134 @@
+package schema_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/debugger84/sqlc-graphql/schema"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalars(t *testing.T) {
+	t.Run(
+		"Marshal the null UUID", func(t *testing.T) {
+			var null bytes.Buffer
+			schema.MarshalNullUUID(uuid.NullUUID{}).MarshalGQL(&null)
+			id, err := schema.UnmarshalNullUUID("9b2f4c3e-1a5d-4f0e-8c7b-2d6e8f1a3b4c")
+
+			t.Log("Given the null and the valid UUID")
+			t.Log("When they are marshaled and unmarshaled")
+			t.Log("	Then the null UUID should be written as null")
+			require.Equal(t, "null", null.String())
+			t.Log("	And the valid UUID should be parsed")
+			require.NoError(t, err)
+			require.True(t, id.Valid)
+		},
+	)
+
+	t.Run(
+		"Marshal the JSON document", func(t *testing.T) {
+			var out bytes.Buffer
+			schema.MarshalJSON(json.RawMessage(`{"a":1}`)).MarshalGQL(&out)
+			doc, err := schema.UnmarshalJSON(map[string]any{"b": []any{true}})
+
+			t.Log("Given the JSON document")
+			t.Log("When it is marshaled and unmarshaled")
+			t.Log("	Then the document should be written as the object")
+			require.Equal(t, `{"a":1}`, out.String())
+			t.Log("	And the input object should be encoded as JSON")
+			require.NoError(t, err)
+			require.JSONEq(t, `{"b":[true]}`, string(doc))
+		},
+	)
+
+	t.Run(
+		"Marshal the nullable JSON document of database/sql", func(t *testing.T) {
+			var out, null bytes.Buffer
+			schema.MarshalNullRawMessage(
+				pqtype.NullRawMessage{RawMessage: json.RawMessage(`{"a":1}`), Valid: true},
+			).MarshalGQL(&out)
+			schema.MarshalNullRawMessage(pqtype.NullRawMessage{}).MarshalGQL(&null)
+			doc, err := schema.UnmarshalNullRawMessage(map[string]any{"b": 2})
+			require.NoError(t, err)
+			nullDoc, err := schema.UnmarshalNullRawMessage(nil)
+			require.NoError(t, err)
+
+			t.Log("Given the valid and the null JSON documents of pqtype")
+			t.Log("When they are marshaled and unmarshaled")
+			t.Log("	Then the valid document should be written as the object and the null one as null")
+			require.Equal(t, `{"a":1}`, out.String())
+			require.Equal(t, "null", null.String())
+			t.Log("	And the input object should be read as the valid document")
+			require.True(t, doc.Valid)
+			require.JSONEq(t, `{"b":2}`, string(doc.RawMessage))
+			t.Log("	And null should be read as the SQL null")
+			require.False(t, nullDoc.Valid)
+		},
+	)
+
+	t.Run(
+		"Keep all the digits of the 64-bit integers", func(t *testing.T) {
+			var number, str bytes.Buffer
+			schema.MarshalInt64(9007199254740993).MarshalGQL(&number)
+			schema.MarshalBigInt(9007199254740993).MarshalGQL(&str)
+			fromString, err := schema.UnmarshalBigInt("9007199254740993")
+			require.NoError(t, err)
+			fromNumber, err := schema.UnmarshalInt64(json.Number("9007199254740993"))
+			require.NoError(t, err)
+			_, fractionErr := schema.UnmarshalInt64(1.5)
+
+			t.Log("Given the integer greater than the largest exact float64 integer")
+			t.Log("When it is marshaled and unmarshaled")
+			t.Log("	Then Int64 should write it as the number and BigInt as the string")
+			require.Equal(t, "9007199254740993", number.String())
+			require.Equal(t, `"9007199254740993"`, str.String())
+			t.Log("	And both should read it without rounding")
+			require.Equal(t, int64(9007199254740993), fromString)
+			require.Equal(t, int64(9007199254740993), fromNumber)
+			t.Log("	And the number with the fraction should be rejected")
+			require.Error(t, fractionErr)
+		},
+	)
+
+	t.Run(
+		"Reject the smallint out of its range", func(t *testing.T) {
+			max, err := schema.UnmarshalInt16(32767)
+			require.NoError(t, err)
+			_, overflowErr := schema.UnmarshalInt16(32768)
+			_, underflowErr := schema.UnmarshalInt16(json.Number("-32769"))
+
+			t.Log("Given the integers at and beyond the bounds of the smallint")
+			t.Log("When they are unmarshaled")
+			t.Log("	Then the bound should be read as is")
+			require.Equal(t, int16(32767), max)
+			t.Log("	And the integers beyond the bounds should be rejected instead of being wrapped")
+			require.EqualError(t, overflowErr, "32768 overflows the 16-bit integer")
+			require.EqualError(t, underflowErr, "-32769 overflows the 16-bit integer")
+		},
+	)
+
+	t.Run(
+		"Keep the precision of the decimals", func(t *testing.T) {
+			var out, null bytes.Buffer
+			schema.MarshalDecimal("12345678901234567890.000000001").MarshalGQL(&out)
+			schema.MarshalNullDecimal(sql.NullString{}).MarshalGQL(&null)
+			d, err := schema.UnmarshalDecimal(json.Number("0.10"))
+			require.NoError(t, err)
+			_, invalidErr := schema.UnmarshalDecimal("1.2.3")
+
+			t.Log("Given the decimal with more digits than float64 holds")
+			t.Log("When it is marshaled and unmarshaled")
+			t.Log("	Then it should be written as the string with all the digits")
+			require.Equal(t, `"12345678901234567890.000000001"`, out.String())
+			require.Equal(t, "null", null.String())
+			t.Log("	And the number should be read as it is written")
+			require.Equal(t, "0.10", d)
+			t.Log("	And the value that is not a number should be rejected")
+			require.Error(t, invalidErr)
+		},
+	)
+}